@@ -0,0 +1,589 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const copierPath = "github.com/jinzhu/copier"
+
+// pair is a source and destination type name
+type pair struct {
+	from, to string
+}
+
+// field is a field of a struct, as listed by copier's deepFields
+type field struct {
+	v   *types.Var
+	tag reflect.StructTag
+}
+
+type generator struct {
+	pkg     *types.Package
+	imports map[string]string
+	scanner *types.Interface
+	valuer  *types.Interface
+	buf     bytes.Buffer
+}
+
+// generate loads the package in dir, ignoring the file named output, and returns the
+// source of the copy functions for the given pairs
+func generate(dir, output string, pairs []pair) ([]byte, error) {
+	buildPkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range buildPkg.GoFiles {
+		if name == output {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	imp := importer.ForCompiler(fset, "source", nil)
+	conf := types.Config{Importer: imp}
+	pkg, err := conf.Check(buildPkg.ImportPath, fset, files, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{pkg: pkg, imports: map[string]string{copierPath: "copier"}}
+	if g.scanner, err = lookupInterface(imp, "database/sql", "Scanner"); err != nil {
+		return nil, err
+	}
+	if g.valuer, err = lookupInterface(imp, "database/sql/driver", "Valuer"); err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "func init() {\n")
+	for _, p := range pairs {
+		fmt.Fprintf(&body, "copier.Register(%s)\n", funcName(p))
+	}
+	fmt.Fprintf(&body, "}\n")
+
+	for _, p := range pairs {
+		if err := g.generatePair(p); err != nil {
+			return nil, fmt.Errorf("%s:%s: %w", p.from, p.to, err)
+		}
+	}
+	body.Write(g.buf.Bytes())
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by copiergen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg.Name())
+	paths := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if isStd(paths[i]) != isStd(paths[j]) {
+			return isStd(paths[i])
+		}
+		return paths[i] < paths[j]
+	})
+	for i, path := range paths {
+		if i > 0 && isStd(path) != isStd(paths[i-1]) {
+			fmt.Fprintf(&src, "\n")
+		}
+		fmt.Fprintf(&src, "%q\n", path)
+	}
+	fmt.Fprintf(&src, ")\n\n")
+	src.Write(body.Bytes())
+
+	return format.Source(src.Bytes())
+}
+
+// isStd reports whether path is a standard library package
+func isStd(path string) bool {
+	return !strings.Contains(strings.Split(path, "/")[0], ".")
+}
+
+func lookupInterface(imp types.Importer, path, name string) (*types.Interface, error) {
+	pkg, err := imp.Import(path)
+	if err != nil {
+		return nil, err
+	}
+	return pkg.Scope().Lookup(name).Type().Underlying().(*types.Interface), nil
+}
+
+func funcName(p pair) string {
+	return "copy" + p.from + "To" + p.to
+}
+
+// generatePair writes the copy function of a pair, following the same steps as copier.Copy:
+// fields to fields or setter methods, then getter methods to fields, then the must tags
+func (g *generator) generatePair(p pair) error {
+	from, err := g.lookupStruct(p.from)
+	if err != nil {
+		return err
+	}
+	to, err := g.lookupStruct(p.to)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(&g.buf, "\n// %s copies %s into %s, the same way copier.Copy does\n", funcName(p), p.from, p.to)
+	fmt.Fprintf(&g.buf, "func %s(to *%s, from *%s) error {\n", funcName(p), p.to, p.from)
+
+	flags := map[string]string{}
+	for _, f := range deepFields(to) {
+		if tag := f.tag.Get("copier"); tag != "" {
+			flags[f.v.Name()] = tag
+		}
+	}
+	for _, f := range append(deepFields(from), deepFields(to)...) {
+		if path := tagPath(f.tag.Get("copier")); path != "" {
+			return fmt.Errorf("field %s has path tag %q, paths are not supported", f.v.Name(), path)
+		}
+	}
+	copied, allocated := map[string]bool{}, map[string]bool{}
+
+	// embedded structs are copied as a whole into the dest embedded structs of the same name
+	embedded := map[*types.Var]bool{}
+	for _, f := range embeddedStructs(from) {
+		name := f.v.Name()
+		fromPath, ok := g.fieldPath(from, name)
+		if !ok || fromPath[len(fromPath)-1] != f.v || !f.v.Exported() || inPath(fromPath[:len(fromPath)-1], embedded) {
+			continue
+		}
+		toPath, ok := g.fieldPath(to, name)
+		if !ok || !toPath[len(toPath)-1].Embedded() || !toPath[len(toPath)-1].Exported() || hasTag(embeddedTag(to, toPath[len(toPath)-1]), "-") {
+			continue
+		}
+		if _, ok := g.elem(toPath[len(toPath)-1].Type()).Underlying().(*types.Struct); !ok {
+			continue
+		}
+
+		embedded[f.v] = true
+		if err := g.guard(fromPath[:len(fromPath)-1], allocated, func(allocated map[string]bool) error {
+			if err := g.allocate(toPath[:len(toPath)-1], allocated, name); err != nil {
+				return err
+			}
+			g.assignEmbedded(g.selector("to", toPath), g.selector("from", fromPath), toPath[len(toPath)-1].Type(), f.v.Type())
+			return nil
+		}); err != nil {
+			return err
+		}
+		for flagged := range flags {
+			if path, ok := g.fieldPath(to, flagged); ok && inPath(path, map[*types.Var]bool{toPath[len(toPath)-1]: true}) {
+				copied[flagged] = true
+			}
+		}
+	}
+
+	for _, name := range uniqueNames(deepFields(from)) {
+		if hasTag(flags[name], "-") {
+			continue
+		}
+
+		fromPath, ok := g.fieldPath(from, name)
+		if !ok || inPath(fromPath, embedded) {
+			continue
+		}
+		fromExpr, fromField := g.selector("from", fromPath), fromPath[len(fromPath)-1]
+
+		if err := g.guard(fromPath[:len(fromPath)-1], allocated, func(allocated map[string]bool) error {
+			if toPath, ok := g.fieldPath(to, name); ok {
+				if err := g.allocate(toPath[:len(toPath)-1], allocated, name); err != nil {
+					return err
+				}
+
+				toField := toPath[len(toPath)-1]
+				if !toField.Exported() {
+					return nil
+				}
+				g.assign(g.selector("to", toPath), fromExpr, toField.Type(), fromField.Type(), true)
+				copied[name] = true
+			} else if method := g.method(to, name); method != nil && ast.IsExported(name) {
+				sig := method.Type().(*types.Signature)
+				if sig.Params().Len() == 1 && !sig.Variadic() && types.AssignableTo(fromField.Type(), sig.Params().At(0).Type()) {
+					fmt.Fprintf(&g.buf, "to.%s(%s)\n", name, fromExpr)
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range uniqueNames(deepFields(to)) {
+		method := g.method(from, name)
+		if method == nil || !ast.IsExported(name) {
+			continue
+		}
+		sig := method.Type().(*types.Signature)
+		if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+			continue
+		}
+
+		toPath, ok := g.fieldPath(to, name)
+		if !ok || !toPath[len(toPath)-1].Exported() {
+			continue
+		}
+		g.assign(g.selector("to", toPath), "from."+name+"()", toPath[len(toPath)-1].Type(), sig.Results().At(0).Type(), false)
+	}
+
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if hasTag(flags[name], "must") && !hasTag(flags[name], "-") && !copied[name] {
+			return fmt.Errorf("field %s has must tag but is never copied", name)
+		}
+	}
+
+	fmt.Fprintf(&g.buf, "return nil\n}\n")
+	return nil
+}
+
+// guard writes the statements of body in a nil check of the embedded pointers of the source
+// path, fields promoted through nil embedded pointers are not present and left as is. The dest
+// pointers allocated in the check are allocated again after it
+func (g *generator) guard(path []*types.Var, allocated map[string]bool, body func(allocated map[string]bool) error) error {
+	var checks []string
+	for i, v := range path {
+		if g.isPointer(v.Type()) {
+			checks = append(checks, g.selector("from", path[:i+1])+" != nil")
+		}
+	}
+	if len(checks) == 0 {
+		return body(allocated)
+	}
+
+	fmt.Fprintf(&g.buf, "if %s {\n", strings.Join(checks, " && "))
+	guarded := make(map[string]bool, len(allocated))
+	for expr := range allocated {
+		guarded[expr] = true
+	}
+	if err := body(guarded); err != nil {
+		return err
+	}
+	fmt.Fprintf(&g.buf, "}\n")
+	return nil
+}
+
+// allocate writes the statements allocating the nil embedded pointers of path, once
+func (g *generator) allocate(path []*types.Var, allocated map[string]bool, name string) error {
+	for i, v := range path {
+		ptr, isPtr := v.Type().Underlying().(*types.Pointer)
+		if !isPtr {
+			continue
+		}
+		if !v.Exported() {
+			return fmt.Errorf("field %s is promoted through unexported embedded pointer %s", name, v.Name())
+		}
+		expr := g.selector("to", path[:i+1])
+		if allocated[expr] {
+			continue
+		}
+		allocated[expr] = true
+		fmt.Fprintf(&g.buf, "if %s == nil {\n%s = new(%s)\n}\n", expr, expr, g.typeString(ptr.Elem()))
+	}
+	return nil
+}
+
+// assignEmbedded writes the statements copying the embedded struct src into the embedded struct
+// dst, nil src pointers leave dst as is and dst pointers are allocated instead of shared
+func (g *generator) assignEmbedded(dst, src string, dt, st types.Type) {
+	dstIsPtr, srcIsPtr := g.isPointer(dt), g.isPointer(st)
+	if srcIsPtr {
+		fmt.Fprintf(&g.buf, "if %s != nil {\n", src)
+	}
+	if dstIsPtr {
+		fmt.Fprintf(&g.buf, "if %s == nil {\n%s = new(%s)\n}\n", dst, dst, g.typeString(g.elem(dt)))
+	}
+
+	if types.Identical(g.elem(dt), g.elem(st)) {
+		fmt.Fprintf(&g.buf, "%s = %s\n", deref(dst, dstIsPtr), deref(src, srcIsPtr))
+	} else {
+		fmt.Fprintf(&g.buf, "if err := copier.CopyField(%s, %s); err != nil {\nreturn err\n}\n", ref(dst, dstIsPtr), ref(src, srcIsPtr))
+	}
+
+	if srcIsPtr {
+		fmt.Fprintf(&g.buf, "}\n")
+	}
+}
+
+// deref returns the expression of the value pointed by expr, if it is a pointer
+func deref(expr string, isPtr bool) string {
+	if isPtr {
+		return "*" + expr
+	}
+	return expr
+}
+
+// ref returns the expression of a pointer to expr, if it isn't a pointer
+func ref(expr string, isPtr bool) string {
+	if isPtr {
+		return expr
+	}
+	return "&" + expr
+}
+
+// elem returns the type pointed by t, if t is a pointer
+func (g *generator) elem(t types.Type) types.Type {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+	return t
+}
+
+// assign writes the statements copying the src expression into the dst expression, plain
+// assignments and conversions are used for the types copier would convert, anything else is
+// delegated to copier.CopyField
+func (g *generator) assign(dst, src string, dt, st types.Type, addressable bool) {
+	dptr, dstIsPtr := dt.Underlying().(*types.Pointer)
+	sptr, srcIsPtr := st.Underlying().(*types.Pointer)
+	_, dstIsInterface := dt.Underlying().(*types.Interface)
+
+	switch {
+	case !dstIsPtr && !srcIsPtr && g.convertible(st, dt):
+		fmt.Fprintf(&g.buf, "%s = %s\n", dst, g.convert(src, st, dt))
+		return
+	case dstIsPtr && srcIsPtr && types.Identical(dt, st) && !g.isPointer(dptr.Elem()) && !g.isScanner(dptr.Elem()):
+		fmt.Fprintf(&g.buf, "if value := %s; value == nil {\n%s = nil\n} else {\n", src, dst)
+		fmt.Fprintf(&g.buf, "if %s == nil {\n%s = new(%s)\n}\n*%s = *value\n}\n", dst, dst, g.typeString(dptr.Elem()), dst)
+		return
+	case dstIsPtr && !srcIsPtr && !g.isPointer(dptr.Elem()) && !g.isValuer(st) && g.convertible(st, dptr.Elem()):
+		fmt.Fprintf(&g.buf, "if %s == nil {\n%s = new(%s)\n}\n", dst, dst, g.typeString(dptr.Elem()))
+		fmt.Fprintf(&g.buf, "*%s = %s\n", dst, g.convert(src, st, dptr.Elem()))
+		return
+	case !dstIsPtr && srcIsPtr && !dstIsInterface && !g.isPointer(sptr.Elem()) && !g.isScanner(dt) && !g.isValuer(st) && g.convertible(sptr.Elem(), dt):
+		fmt.Fprintf(&g.buf, "if value := %s; value != nil {\n%s = %s\n}\n", src, dst, g.convert("*value", sptr.Elem(), dt))
+		return
+	}
+
+	if addressable {
+		fmt.Fprintf(&g.buf, "if err := copier.CopyField(&%s, &%s); err != nil {\nreturn err\n}\n", dst, src)
+	} else {
+		fmt.Fprintf(&g.buf, "{\nvalue := %s\nif err := copier.CopyField(&%s, &value); err != nil {\nreturn err\n}\n}\n", src, dst)
+	}
+}
+
+// convertible reports whether copier would convert st into dt with a plain conversion
+func (g *generator) convertible(st, dt types.Type) bool {
+	if !types.ConvertibleTo(st, dt) {
+		return false
+	}
+
+	// integers are formatted into strings by copier, not converted into runes
+	sb, ok := st.Underlying().(*types.Basic)
+	db, ok2 := dt.Underlying().(*types.Basic)
+	if ok && ok2 && sb.Info()&types.IsInteger != 0 && db.Info()&types.IsString != 0 {
+		return false
+	}
+
+	// enums, named types of basic types, may be copied with tables registered with
+	// copier.RegisterEnum, which are only known at run time
+	return types.Identical(st, dt) || !(ok && isNamed(st) || ok2 && isNamed(dt))
+}
+
+func isNamed(t types.Type) bool {
+	_, ok := t.(*types.Named)
+	return ok
+}
+
+func (g *generator) convert(src string, st, dt types.Type) string {
+	if types.Identical(st, dt) {
+		return src
+	}
+	return fmt.Sprintf("%s(%s)", g.typeString(dt), src)
+}
+
+func (g *generator) isPointer(t types.Type) bool {
+	_, ok := t.Underlying().(*types.Pointer)
+	return ok
+}
+
+func (g *generator) isScanner(t types.Type) bool {
+	return types.Implements(types.NewPointer(t), g.scanner)
+}
+
+func (g *generator) isValuer(t types.Type) bool {
+	return types.Implements(t, g.valuer) || types.Implements(types.NewPointer(t), g.valuer)
+}
+
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, func(pkg *types.Package) string {
+		if pkg == g.pkg {
+			return ""
+		}
+		g.imports[pkg.Path()] = pkg.Name()
+		return pkg.Name()
+	})
+}
+
+func (g *generator) lookupStruct(name string) (*types.Named, error) {
+	obj, ok := g.pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("type %s not found in package %s", name, g.pkg.Path())
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("type %s is not a named type", name)
+	}
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return nil, fmt.Errorf("type %s is not a struct", name)
+	}
+	return named, nil
+}
+
+// fieldPath returns the fields leading to the field name of t, following Go's promotion rules
+func (g *generator) fieldPath(t types.Type, name string) ([]*types.Var, bool) {
+	obj, index, _ := types.LookupFieldOrMethod(t, true, g.pkg, name)
+	if _, ok := obj.(*types.Var); !ok {
+		return nil, false
+	}
+
+	path := make([]*types.Var, 0, len(index))
+	for _, i := range index {
+		if ptr, ok := t.Underlying().(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		v := t.Underlying().(*types.Struct).Field(i)
+		path = append(path, v)
+		t = v.Type()
+	}
+	return path, true
+}
+
+// method returns the method name of *t
+func (g *generator) method(t types.Type, name string) *types.Func {
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(t), true, g.pkg, name)
+	method, _ := obj.(*types.Func)
+	return method
+}
+
+func (g *generator) selector(base string, path []*types.Var) string {
+	names := []string{base}
+	for _, v := range path {
+		names = append(names, v.Name())
+	}
+	return strings.Join(names, ".")
+}
+
+// deepFields lists the fields of a struct, promoting the fields of embedded structs like copier
+// does, shadowed and ambiguous fields are left out
+func deepFields(t types.Type) []field {
+	var fields []field
+	for _, f := range embeddedFields(t) {
+		if obj, _, _ := types.LookupFieldOrMethod(t, true, f.v.Pkg(), f.v.Name()); obj == f.v {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// embeddedFields lists the fields of a struct and of its embedded structs
+func embeddedFields(t types.Type) []field {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+
+	fields := make([]field, 0, st.NumFields())
+	for i := 0; i < st.NumFields(); i++ {
+		if v := st.Field(i); v.Embedded() {
+			fields = append(fields, embeddedFields(v.Type())...)
+		} else {
+			fields = append(fields, field{v: v, tag: reflect.StructTag(st.Tag(i))})
+		}
+	}
+	return fields
+}
+
+// embeddedStructs lists the embedded fields of a struct and of its embedded structs
+func embeddedStructs(t types.Type) []field {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+
+	var fields []field
+	for i := 0; i < st.NumFields(); i++ {
+		if v := st.Field(i); v.Embedded() {
+			fields = append(fields, field{v: v, tag: reflect.StructTag(st.Tag(i))})
+			fields = append(fields, embeddedStructs(v.Type())...)
+		}
+	}
+	return fields
+}
+
+// embeddedTag returns the copier tag of the embedded field v of t
+func embeddedTag(t types.Type, v *types.Var) string {
+	for _, f := range embeddedStructs(t) {
+		if f.v == v {
+			return f.tag.Get("copier")
+		}
+	}
+	return ""
+}
+
+// inPath reports whether path goes through one of the fields
+func inPath(path []*types.Var, fields map[*types.Var]bool) bool {
+	for _, v := range path {
+		if fields[v] {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueNames(fields []field) []string {
+	seen := map[string]bool{}
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if !seen[f.v.Name()] {
+			seen[f.v.Name()] = true
+			names = append(names, f.v.Name())
+		}
+	}
+	return names
+}
+
+// tagPath returns the first value of the tags which is not a flag
+func tagPath(tags string) string {
+	for _, t := range strings.Split(tags, ",") {
+		switch t {
+		case "", "-", "must", "nopanic", "key", "value":
+		default:
+			return t
+		}
+	}
+	return ""
+}
+
+func hasTag(tags, name string) bool {
+	for _, t := range strings.Split(tags, ",") {
+		if t == name {
+			return true
+		}
+	}
+	return false
+}
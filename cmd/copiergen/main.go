@@ -0,0 +1,57 @@
+// Command copiergen generates reflection-free copy functions for pairs of struct types in a
+// package, with the same semantics as copier.Copy, and registers them so copier.Copy
+// dispatches to the generated code instead of using reflection.
+//
+// Fields converted between different named types of basic types, such as enums, are copied
+// with copier.CopyField, so the tables registered with copier.RegisterEnum are used.
+//
+// Pairs are given as From:To type names, for example:
+//
+//	//go:generate go run github.com/jinzhu/copier/cmd/copiergen -output copier_gen.go User:Employee
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	var (
+		dir    = flag.String("dir", ".", "directory of the package declaring the types")
+		output = flag.String("output", "copier_gen.go", "name of the generated file, relative to dir")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: copiergen [flags] From:To...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := run(*dir, *output, flag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "copiergen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(dir, output string, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no type pairs given")
+	}
+
+	var pairs []pair
+	for _, arg := range args {
+		names := strings.Split(arg, ":")
+		if len(names) != 2 || names[0] == "" || names[1] == "" {
+			return fmt.Errorf("invalid type pair %q, expected From:To", arg)
+		}
+		pairs = append(pairs, pair{from: names[0], to: names[1]})
+	}
+
+	src, err := generate(dir, filepath.Base(output), pairs)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, output), src, 0o644)
+}
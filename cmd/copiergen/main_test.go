@@ -0,0 +1,91 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/copier"
+	"github.com/jinzhu/copier/cmd/copiergen/testdata/example"
+)
+
+func TestGenerate(t *testing.T) {
+	src, err := generate("testdata/example", "copier_gen.go", []pair{{from: "User", to: "Employee"}, {from: "Account", to: "AccountRow"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	golden, err := os.ReadFile("testdata/example/copier_gen.go")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(src) != string(golden) {
+		t.Errorf("Generated code doesn't match testdata/example/copier_gen.go, run go generate in testdata/example, got:\n%s", src)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	if _, err := generate("testdata/example", "copier_gen.go", []pair{{from: "User", to: "Manager"}}); err == nil || !strings.Contains(err.Error(), "Title") {
+		t.Errorf("Should fail on must field without source, got %v", err)
+	}
+
+	if _, err := generate("testdata/example", "copier_gen.go", []pair{{from: "User", to: "Unknown"}}); err == nil {
+		t.Errorf("Should fail on unknown type")
+	}
+
+	if err := run("testdata/example", "copier_gen.go", []string{"User"}); err == nil {
+		t.Errorf("Should fail on invalid pair")
+	}
+}
+
+func TestGeneratedCopy(t *testing.T) {
+	var (
+		birthday = time.Now()
+		fakeAge  = int32(12)
+		user     = example.User{
+			Base:     example.Base{ID: 1, CreatedAt: birthday},
+			Name:     "Jinzhu",
+			Nickname: "jinzhu",
+			Birthday: &birthday,
+			Age:      18,
+			FakeAge:  &fakeAge,
+			Notes:    []string{"hello", "world"},
+			Status:   "active",
+			Role:     "Admin",
+		}
+		generated, reflected example.Employee
+	)
+	user.Email.Scan("jinzhu@example.com")
+
+	if err := copier.Copy(&generated, &user); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := copier.CopyWithOption(&reflected, &user, copier.Option{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(generated, reflected) {
+		t.Errorf("Generated copy should match reflection, got %#v, expected %#v", generated, reflected)
+	}
+	if generated.SuperRole != "Super Admin" || generated.DoubleAge != 36 || generated.Email != "jinzhu@example.com" {
+		t.Errorf("Methods and valuers should be copied, got %#v", generated)
+	}
+	if generated.Birthday == user.Birthday {
+		t.Errorf("Pointer fields should not be shared")
+	}
+
+	for _, account := range []example.Account{{Login: "jinzhu"}, {Base: &example.Base{ID: 1, CreatedAt: birthday}, Login: "jinzhu"}} {
+		var generated, reflected example.AccountRow
+		if err := copier.Copy(&generated, &account); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if err := copier.CopyWithOption(&reflected, &account, copier.Option{}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if generated != reflected || generated.Login != "jinzhu" {
+			t.Errorf("Generated copy of fields of embedded pointers should match reflection, got %#v, expected %#v", generated, reflected)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Code generated by copiergen. DO NOT EDIT.
+
+package example
+
+import (
+	"time"
+
+	"github.com/jinzhu/copier"
+)
+
+func init() {
+	copier.Register(copyUserToEmployee)
+	copier.Register(copyAccountToAccountRow)
+}
+
+// copyUserToEmployee copies User into Employee, the same way copier.Copy does
+func copyUserToEmployee(to *Employee, from *User) error {
+	if to.Base == nil {
+		to.Base = new(Base)
+	}
+	*to.Base = from.Base
+	to.Name = from.Name
+	if to.Nickname == nil {
+		to.Nickname = new(string)
+	}
+	*to.Nickname = from.Nickname
+	if value := from.Birthday; value == nil {
+		to.Birthday = nil
+	} else {
+		if to.Birthday == nil {
+			to.Birthday = new(time.Time)
+		}
+		*to.Birthday = *value
+	}
+	to.Age = int64(from.Age)
+	if value := from.FakeAge; value != nil {
+		to.FakeAge = int(*value)
+	}
+	if err := copier.CopyField(&to.Notes, &from.Notes); err != nil {
+		return err
+	}
+	if err := copier.CopyField(&to.Status, &from.Status); err != nil {
+		return err
+	}
+	if err := copier.CopyField(&to.Email, &from.Email); err != nil {
+		return err
+	}
+	to.Role(from.Role)
+	to.DoubleAge = from.DoubleAge()
+	return nil
+}
+
+// copyAccountToAccountRow copies Account into AccountRow, the same way copier.Copy does
+func copyAccountToAccountRow(to *AccountRow, from *Account) error {
+	if from.Base != nil {
+		to.ID = from.Base.ID
+	}
+	if from.Base != nil {
+		to.CreatedAt = from.Base.CreatedAt
+	}
+	to.Login = from.Login
+	return nil
+}
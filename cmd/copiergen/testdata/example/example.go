@@ -0,0 +1,69 @@
+//go:generate go run github.com/jinzhu/copier/cmd/copiergen -output copier_gen.go User:Employee Account:AccountRow
+
+package example
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Base struct {
+	ID        int
+	CreatedAt time.Time
+}
+
+type Status string
+
+type User struct {
+	Base
+	Name     string
+	Nickname string
+	Birthday *time.Time
+	Age      int32
+	FakeAge  *int32
+	Notes    []string
+	Status   string
+	Email    sql.NullString
+	Role     string
+	flags    []byte
+}
+
+func (user User) DoubleAge() int32 {
+	return 2 * user.Age
+}
+
+type Employee struct {
+	*Base
+	Name      string `copier:"must"`
+	Nickname  *string
+	Birthday  *time.Time
+	Age       int64
+	FakeAge   int
+	DoubleAge int32
+	Notes     []*string
+	Status    Status
+	Email     string
+	Salary    int `copier:"-"`
+	SuperRole string
+	flags     []byte
+}
+
+func (employee *Employee) Role(role string) {
+	employee.SuperRole = "Super " + role
+}
+
+type Manager struct {
+	Name  string
+	Title string `copier:"must"`
+}
+
+type Account struct {
+	*Base
+	Login string
+}
+
+type AccountRow struct {
+	ID        int
+	CreatedAt time.Time
+	Login     string
+}
@@ -1,11 +1,17 @@
 package copier
 
 import (
+	"context"
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
 )
 
 // These flags define options for tag handling
@@ -20,20 +26,136 @@ const (
 	// Ignore a destination field from being copied to.
 	tagIgnore
 
+	// Denotes the key field of a key/value pair struct, used when copying between maps and slices.
+	tagKey
+
+	// Denotes the value field of a key/value pair struct, used when copying between maps and slices.
+	tagValue
+
 	// Denotes that the value as been copied
 	hasCopied
 )
 
+// UncopyablePolicy defines how channels and functions, which can't be copied, are handled
+type UncopyablePolicy uint8
+
+const (
+	// UncopyableShare assigns the value so it is shared between the original and the copy
+	UncopyableShare UncopyablePolicy = iota
+	// UncopyableSkip leaves the destination untouched
+	UncopyableSkip
+	// UncopyableZero sets the destination to its zero value
+	UncopyableZero
+	// UncopyableError returns ErrUncopyable
+	UncopyableError
+)
+
+// IntToStringPolicy defines how integers are copied into strings
+type IntToStringPolicy uint8
+
+const (
+	// IntToStringDecimal formats integers in base 10, 65 into "65"
+	IntToStringDecimal IntToStringPolicy = iota
+	// IntToStringRune converts integers into the character of their code point, 65 into "A",
+	// like Go conversions do
+	IntToStringRune
+	// IntToStringError returns ErrNotSupported
+	IntToStringError
+)
+
+// StrictMode defines which fields must have a counterpart when copying structs
+type StrictMode uint8
+
+const (
+	// StrictTo requires every exported destination field to be filled
+	StrictTo StrictMode = 1 << iota
+	// StrictFrom requires every exported source field to be copied
+	StrictFrom
+)
+
 // Option sets copy options
 type Option struct {
 	// setting this value to true will ignore copying zero values of all the fields, including bools, as well as a
 	// struct having all it's fields set to their zero values respectively (see IsZero() in reflect/value.go)
 	IgnoreEmpty bool
 	DeepCopy    bool
+	Converters  []TypeConverter
+	// Uncopyable sets how channels and functions are copied, they are shared by default
+	Uncopyable UncopyablePolicy
+	// setting this value to true together with DeepCopy will also copy unexported fields when
+	// both values are of the same type, so the copy is a full clone. Struct values of the types of
+	// other packages, such as time.Time, are copied as a whole instead
+	CopyUnexported bool
+	// Strict returns ErrUnmatchedFields, listing the offending fields, when fields are left
+	// unmatched, fields ignored with the `-` tag are never reported
+	Strict StrictMode
+	// Flatten copies nested source fields into dest fields named after their path, such as
+	// Customer.Name into CustomerName, and such source fields into nested dest fields
+	Flatten bool
+	// FlattenSeparator joins the field names of a flattened path, it is empty by default
+	FlattenSeparator string
+
+	// Workers copies the elements of slices on up to Workers goroutines, into a destination
+	// slice allocated beforehand, converters must then be safe for concurrent use. Elements are
+	// copied one after the other when Workers is 0 or 1
+	Workers int
+
+	// TextMarshaling copies values implementing encoding.TextMarshaler, or else fmt.Stringer,
+	// into strings, and strings into values implementing encoding.TextUnmarshaler, including
+	// map keys, such as UUIDs, IPs or enums
+	TextMarshaling bool
+
+	// Times copies time.Time, *time.Time and sql.NullTime values from and into strings formatted
+	// with TimeLayout and Unix seconds in int64, and time.Duration values from and into strings
+	Times bool
+	// TimeLayout formats and parses the times of strings, time.RFC3339 by default
+	TimeLayout string
+	// TimeLocation is the location of the times parsed from strings without a zone, from Unix
+	// seconds and formatted into strings, UTC by default
+	TimeLocation *time.Location
+
+	// Strconv copies numbers and bools into strings in base 10, such as 65 into "65" rather
+	// than "A", and parses strings into numbers and bools, empty strings are skipped
+	Strconv bool
+	// CheckOverflow fails with ErrOverflow when a number doesn't fit in its destination type,
+	// such as 300 into an int8, -1 into an uint or 1.5 into an int, instead of wrapping or
+	// truncating it
+	CheckOverflow bool
+	// IntToString sets how integers are copied into strings, they are formatted in base 10 by
+	// default
+	IntToString IntToStringPolicy
+
+	// OnScanError is called with the errors of the Scan methods of destinations and the Value
+	// methods of sources, the value is then skipped and the copy goes on. Without it, these
+	// errors stop the copy and are returned as *PathError. It must be safe for concurrent use
+	// with Workers
+	OnScanError func(err *PathError)
+
+	// ctx is the context of CopyContext
+	ctx context.Context
+	// path is the path of the value being copied, when OnScanError is set
+	path *pathNode
+	// cloned is the type of the value cloned with CopyUnexported, unexported fields are only
+	// cloned in the types of its package
+	cloned reflect.Type
+}
+
+// TypeConverter converts values of SrcType into DstType, it is used for fields,
+// slice elements, map keys and map values alike
+type TypeConverter struct {
+	SrcType interface{}
+	DstType interface{}
+	Fn      func(src interface{}) (interface{}, error)
+	// FnContext is used instead of Fn when set, with the context of CopyContext, or
+	// context.Background() for the other copy functions
+	FnContext func(ctx context.Context, src interface{}) (interface{}, error)
 }
 
-// Copy copy things
+// Copy copy things, using the copy function registered for the types if there is one
 func Copy(toValue interface{}, fromValue interface{}) (err error) {
+	if fn, to, from, ok := registered(toValue, fromValue); ok {
+		return fn(to, from)
+	}
 	return copier(toValue, fromValue, Option{})
 }
 
@@ -42,6 +164,16 @@ func CopyWithOption(toValue interface{}, fromValue interface{}, opt Option) (err
 	return copier(toValue, fromValue, opt)
 }
 
+// CopyContext copy with option, checking ctx between the elements of slices and maps and passing
+// it to the converters, when ctx is done the copy stops and returns a *PathError wrapping ctx.Err()
+func CopyContext(ctx context.Context, toValue interface{}, fromValue interface{}, opt Option) error {
+	opt.ctx = ctx
+	if err := opt.done(); err != nil {
+		return err
+	}
+	return copier(toValue, fromValue, opt)
+}
+
 func copier(toValue interface{}, fromValue interface{}, opt Option) (err error) {
 	var (
 		isSlice bool
@@ -65,6 +197,7 @@ func copier(toValue interface{}, fromValue interface{}, opt Option) (err error)
 	if fromType.Kind() == reflect.Interface {
 		fromType = reflect.TypeOf(from.Interface())
 	}
+	opt = opt.cloning(fromType)
 
 	if toType.Kind() == reflect.Interface {
 		toType, _ = indirectType(reflect.TypeOf(to.Interface()))
@@ -75,6 +208,38 @@ func copier(toValue interface{}, fromValue interface{}, opt Option) (err error)
 		}()
 	}
 
+	if ok, err := convert(to, from, opt); ok || err != nil {
+		return err
+	}
+
+	if ok, err := setEnum(to, from); ok || err != nil {
+		return err
+	}
+
+	if ok, err := setUncopyable(to, from, opt); ok || err != nil {
+		return err
+	}
+
+	if ok, err := setNullable(to, from, opt); ok || err != nil {
+		return err
+	}
+
+	if ok, err := setTime(to, from, opt); ok || err != nil {
+		return err
+	}
+
+	if ok, err := setText(to, from, opt); ok || err != nil {
+		return err
+	}
+
+	if ok, err := setNumber(to, from, opt); ok || err != nil {
+		return err
+	}
+
+	if ok, err := setIntString(to, from, opt); ok || err != nil {
+		return err
+	}
+
 	// Just set it if possible to assign for normal types
 	if from.Kind() != reflect.Slice && from.Kind() != reflect.Struct && from.Kind() != reflect.Map && (from.Type().AssignableTo(to.Type()) || from.Type().ConvertibleTo(to.Type())) {
 		if !isPtrFrom || !opt.DeepCopy {
@@ -88,7 +253,7 @@ func copier(toValue interface{}, fromValue interface{}, opt Option) (err error)
 	}
 
 	if fromType.Kind() == reflect.Map && toType.Kind() == reflect.Map {
-		if !fromType.Key().ConvertibleTo(toType.Key()) {
+		if !mapKeyCompatible(fromType.Key(), toType.Key(), opt) {
 			return ErrMapKeyNotMatch
 		}
 
@@ -97,48 +262,145 @@ func copier(toValue interface{}, fromValue interface{}, opt Option) (err error)
 		}
 
 		for _, k := range from.MapKeys() {
-			toKey := indirect(reflect.New(toType.Key()))
-			if !set(toKey, k, opt.DeepCopy) {
-				return fmt.Errorf("%w map, old key: %v, new key: %v", ErrNotSupported, k.Type(), toType.Key())
+			if err = opt.done(); err != nil {
+				return withPath(err, keyPath(k))
 			}
 
-			elemType, _ := indirectType(toType.Elem())
-			toValue := indirect(reflect.New(elemType))
-			if !set(toValue, from.MapIndex(k), opt.DeepCopy) {
-				if err = copier(toValue.Addr().Interface(), from.MapIndex(k).Interface(), opt); err != nil {
-					return err
-				}
+			keyOpt := opt.at(pathNode{key: k})
+			toKey := reflect.New(toType.Key()).Elem()
+			if err = copyValue(toKey, k, keyOpt); err != nil {
+				return withPath(fmt.Errorf("map, old key: %v, new key: %v: %w", k.Type(), toType.Key(), err), keyPath(k))
 			}
 
-			for {
-				if elemType == toType.Elem() {
-					to.SetMapIndex(toKey, toValue)
-					break
-				}
-				elemType = reflect.PtrTo(elemType)
-				toValue = toValue.Addr()
+			toValue := reflect.New(toType.Elem()).Elem()
+			if err = copyValue(toValue, from.MapIndex(k), keyOpt); err != nil {
+				return withPath(err, keyPath(k))
+			}
+
+			to.SetMapIndex(toKey, toValue)
+		}
+		return
+	}
+
+	if fromType.Kind() == reflect.Map && to.Kind() == reflect.Slice && toType.Kind() == reflect.Struct {
+		keyName, valueName, ok := pairFields(toType)
+		if !ok {
+			return
+		}
+
+		to.Set(reflect.MakeSlice(to.Type(), 0, from.Len()))
+		for _, k := range sortedMapKeys(from) {
+			if err = opt.done(); err != nil {
+				return withPath(err, keyPath(k))
+			}
+
+			keyOpt := opt.at(pathNode{key: k})
+			pair := reflect.New(toType).Elem()
+			if err = copyValue(pair.FieldByName(keyName), k, keyOpt); err != nil {
+				return withPath(err, keyPath(k))
+			}
+			if err = copyValue(pair.FieldByName(valueName), from.MapIndex(k), keyOpt); err != nil {
+				return withPath(err, keyPath(k))
+			}
+
+			if to.Type().Elem().Kind() == reflect.Ptr {
+				pair = pair.Addr()
+			}
+			to.Set(reflect.Append(to, pair))
+		}
+		return
+	}
+
+	if from.Kind() == reflect.Slice && fromType.Kind() == reflect.Struct && toType.Kind() == reflect.Map {
+		keyName, valueName, ok := pairFields(fromType)
+		if !ok {
+			return
+		}
+
+		if to.IsNil() {
+			to.Set(reflect.MakeMapWithSize(toType, from.Len()))
+		}
+
+		for i := 0; i < from.Len(); i++ {
+			if err = opt.done(); err != nil {
+				return withPath(err, indexPath(i))
+			}
+
+			pair := indirect(from.Index(i))
+			if !pair.IsValid() {
+				continue
+			}
+
+			pairOpt := opt.at(pathNode{index: i})
+			toKey := reflect.New(toType.Key()).Elem()
+			if err = copyValue(toKey, pair.FieldByName(keyName), pairOpt); err != nil {
+				return withPath(err, indexPath(i))
 			}
+
+			toValue := reflect.New(toType.Elem()).Elem()
+			if err = copyValue(toValue, pair.FieldByName(valueName), pairOpt); err != nil {
+				return withPath(err, indexPath(i))
+			}
+
+			to.SetMapIndex(toKey, toValue)
 		}
 		return
 	}
 
-	if from.Kind() == reflect.Slice && to.Kind() == reflect.Slice && fromType.ConvertibleTo(toType) {
+	if from.Kind() == reflect.Slice && to.Kind() == reflect.Slice && (fromType.ConvertibleTo(toType) || opt.convertible(toType, fromType)) {
 		if to.IsNil() {
 			slice := reflect.MakeSlice(reflect.SliceOf(to.Type().Elem()), from.Len(), from.Cap())
 			to.Set(slice)
 		}
 
-		for i := 0; i < from.Len(); i++ {
+		length := to.Len()
+		if opt.concurrent(from.Len()) && to.Len() < from.Len() {
+			// elements are copied independently into the pre-sized slice
+			to.Set(reflect.AppendSlice(to, reflect.MakeSlice(to.Type(), from.Len()-to.Len(), from.Len()-to.Len())))
+		}
+
+		var (
+			mu        sync.Mutex
+			lastIndex = -1
+			elemOpt   = opt.sequential()
+		)
+		if failed, forErr := forEach(from.Len(), opt, func(i int) error {
+			if err := opt.done(); err != nil {
+				return withPath(err, indexPath(i))
+			}
+
 			if to.Len() < i+1 {
 				to.Set(reflect.Append(to, reflect.New(to.Type().Elem()).Elem()))
 			}
 
-			if !set(to.Index(i), from.Index(i), opt.DeepCopy) {
-				err = CopyWithOption(to.Index(i).Addr().Interface(), from.Index(i).Interface(), opt)
-				if err != nil {
-					continue
+			indexOpt := elemOpt.at(pathNode{index: i})
+			copied, setErr := set(to.Index(i), from.Index(i), indexOpt)
+			if setErr != nil {
+				return withPath(setErr, indexPath(i))
+			}
+			if !copied {
+				copyErr := CopyWithOption(to.Index(i).Addr().Interface(), from.Index(i).Interface(), indexOpt)
+				if copyErr != nil && opt.done() != nil {
+					return withPath(copyErr, indexPath(i))
+				}
+
+				// the error of the last element copied with CopyWithOption is returned
+				mu.Lock()
+				if i > lastIndex {
+					lastIndex, err = i, copyErr
 				}
+				mu.Unlock()
+			}
+			return nil
+		}); forErr != nil {
+			// like when copying sequentially, the slice ends with the failed element
+			if failed+1 > length {
+				length = failed + 1
 			}
+			if to.Len() > length {
+				to.SetLen(length)
+			}
+			return forErr
 		}
 		return
 	}
@@ -155,9 +417,28 @@ func copier(toValue interface{}, fromValue interface{}, opt Option) (err error)
 		}
 	}
 
-	for i := 0; i < amount; i++ {
+	// with workers, the new elements are set into the pre-sized slice from index grownFrom
+	grownFrom := -1
+	if isSlice && opt.concurrent(amount) && to.Len() < amount {
+		grownFrom = to.Len()
+		to.Set(reflect.AppendSlice(to, reflect.MakeSlice(to.Type(), amount-to.Len(), amount-to.Len())))
+	}
+
+	elemOpt := opt
+	if isSlice {
+		elemOpt = opt.sequential()
+	}
+
+	var mu sync.Mutex
+	copyElement := func(i int) error {
 		var dest, source reflect.Value
 
+		if isSlice && from.Kind() == reflect.Slice {
+			if err := opt.done(); err != nil {
+				return withPath(err, indexPath(i))
+			}
+		}
+
 		if isSlice {
 			// source
 			if from.Kind() == reflect.Slice {
@@ -180,127 +461,68 @@ func copier(toValue interface{}, fromValue interface{}, opt Option) (err error)
 		}
 
 		// Get tag options
-		tagBitFlags := map[string]uint8{}
-		if dest.IsValid() {
-			tagBitFlags = getBitFlags(toType)
+		sourceType := fromType
+		if source.IsValid() {
+			sourceType = source.Type()
 		}
+		mapping := getMapping(dest.Type(), sourceType, opt)
+		tagBitFlags := mapping.tagBitFlags()
 
 		// check source
 		if source.IsValid() {
-			// Copy from source field to dest field or method
-			fromTypeFields := deepFields(fromType)
-			for _, field := range fromTypeFields {
-				name := field.Name
-
-				// Get bit flags for field
-				fieldFlags, _ := tagBitFlags[name]
-
-				// Check if we should ignore copying
-				if (fieldFlags & tagIgnore) != 0 {
-					continue
-				}
-
-				if fromField := source.FieldByName(name); fromField.IsValid() && !shouldIgnore(fromField, opt.IgnoreEmpty) {
-					// process for nested anonymous field
-					destFieldNotSet := false
-					if f, ok := dest.Type().FieldByName(name); ok {
-						for idx := range f.Index {
-							destField := dest.FieldByIndex(f.Index[:idx+1])
-
-							if destField.Kind() != reflect.Ptr {
-								continue
-							}
-
-							if !destField.IsNil() {
-								continue
-							}
-							if !destField.CanSet() {
-								destFieldNotSet = true
-								break
-							}
-
-							// destField is a nil pointer that can be set
-							newValue := reflect.New(destField.Type().Elem())
-							destField.Set(newValue)
-						}
-					}
-
-					if destFieldNotSet {
-						break
-					}
-
-					toField := dest.FieldByName(name)
-					if toField.IsValid() {
-						if toField.CanSet() {
-							if !set(toField, fromField, opt.DeepCopy) {
-								if err := copier(toField.Addr().Interface(), fromField.Interface(), opt); err != nil {
-									return err
-								}
-							}
-							if fieldFlags != 0 {
-								// Note that a copy was made
-								tagBitFlags[name] = fieldFlags | hasCopied
-							}
-						}
-					} else {
-						// try to set to method
-						var toMethod reflect.Value
-						if dest.CanAddr() {
-							toMethod = dest.Addr().MethodByName(name)
-						} else {
-							toMethod = dest.MethodByName(name)
-						}
-
-						if toMethod.IsValid() && toMethod.Type().NumIn() == 1 && fromField.Type().AssignableTo(toMethod.Type().In(0)) {
-							toMethod.Call([]reflect.Value{fromField})
-						}
-					}
-				}
+			if err := mapping.checkStrict(opt.Strict); err != nil {
+				return err
 			}
-
-			// Copy from from method to dest field
-			for _, field := range deepFields(toType) {
-				name := field.Name
-
-				var fromMethod reflect.Value
-				if source.CanAddr() {
-					fromMethod = source.Addr().MethodByName(name)
-				} else {
-					fromMethod = source.MethodByName(name)
-				}
-
-				if fromMethod.IsValid() && fromMethod.Type().NumIn() == 0 && fromMethod.Type().NumOut() == 1 && !shouldIgnore(fromMethod, opt.IgnoreEmpty) {
-					if toField := dest.FieldByName(name); toField.IsValid() && toField.CanSet() {
-						values := fromMethod.Call([]reflect.Value{})
-						if len(values) >= 1 {
-							set(toField, values[0], opt.DeepCopy)
-						}
-					}
+			sourceOpt := elemOpt
+			if isSlice && from.Kind() == reflect.Slice {
+				sourceOpt = elemOpt.at(pathNode{index: i})
+			}
+			if err := mapping.copy(dest, source, tagBitFlags, sourceOpt); err != nil {
+				if isSlice && from.Kind() == reflect.Slice {
+					return withPath(err, indexPath(i))
 				}
+				return err
 			}
 		}
 
 		if isSlice {
+			elem := dest
 			if dest.Addr().Type().AssignableTo(to.Type().Elem()) {
-				if to.Len() < i+1 {
-					to.Set(reflect.Append(to, dest.Addr()))
-				} else {
-					set(to.Index(i), dest.Addr(), opt.DeepCopy)
-				}
-			} else if dest.Type().AssignableTo(to.Type().Elem()) {
-				if to.Len() < i+1 {
-					to.Set(reflect.Append(to, dest))
-				} else {
-					set(to.Index(i), dest, opt.DeepCopy)
+				elem = dest.Addr()
+			}
+
+			if elem.Type().AssignableTo(to.Type().Elem()) {
+				switch {
+				case grownFrom != -1 && i >= grownFrom:
+					to.Index(i).Set(elem)
+				case to.Len() < i+1:
+					to.Set(reflect.Append(to, elem))
+				default:
+					set(to.Index(i), elem, opt)
 				}
 			}
 		} else if initDest {
 			to.Set(dest)
 		}
 
-		err = checkBitFlags(tagBitFlags)
+		// the must check of the last element is returned
+		if flagsErr := checkBitFlags(tagBitFlags); i == amount-1 {
+			mu.Lock()
+			err = flagsErr
+			mu.Unlock()
+		}
+		return nil
 	}
 
+	if failed, copyErr := forEach(amount, opt, copyElement); copyErr != nil {
+		// like when copying sequentially, the slice ends before the failed element
+		if grownFrom != -1 && failed > grownFrom {
+			to.SetLen(failed)
+		} else if grownFrom != -1 {
+			to.SetLen(grownFrom)
+		}
+		return copyErr
+	}
 	return
 }
 
@@ -312,15 +534,21 @@ func shouldIgnore(v reflect.Value, ignoreEmpty bool) bool {
 	return v.IsZero()
 }
 
+// exportedValue returns a settable value for an addressable unexported field
+func exportedValue(v reflect.Value) reflect.Value {
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
+
+// deepFields returns the fields of a struct, promoting the fields of embedded structs the way Go
+// does: fields shadow the fields of the same name deeper in embedded structs, and fields of the
+// same name at the same depth are ambiguous and left out, see ambiguousFields
 func deepFields(reflectType reflect.Type) []reflect.StructField {
 	if reflectType, _ = indirectType(reflectType); reflectType.Kind() == reflect.Struct {
-		fields := make([]reflect.StructField, 0, reflectType.NumField())
+		visible := reflect.VisibleFields(reflectType)
+		fields := make([]reflect.StructField, 0, len(visible))
 
-		for i := 0; i < reflectType.NumField(); i++ {
-			v := reflectType.Field(i)
-			if v.Anonymous {
-				fields = append(fields, deepFields(v.Type)...)
-			} else {
+		for _, v := range visible {
+			if !v.Anonymous {
 				fields = append(fields, v)
 			}
 		}
@@ -331,6 +559,55 @@ func deepFields(reflectType reflect.Type) []reflect.StructField {
 	return nil
 }
 
+// ambiguousField is the name of fields of embedded structs that can't be promoted because
+// several fields have that name at the same depth, and their indexes
+type ambiguousField struct {
+	name    string
+	indexes [][]int
+}
+
+// ambiguousFields returns the fields of embedded structs that can't be promoted
+func ambiguousFields(reflectType reflect.Type) (fields []ambiguousField) {
+	if reflectType, _ = indirectType(reflectType); reflectType.Kind() != reflect.Struct {
+		return nil
+	}
+
+	positions, embedding := map[string]int{}, map[reflect.Type]bool{}
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		embedding[t] = true
+		for i := 0; i < t.NumField(); i++ {
+			field, fieldIndex := t.Field(i), append(append([]int{}, index...), i)
+			if field.Anonymous {
+				if elem := indirectElem(field.Type); elem.Kind() == reflect.Struct && !embedding[elem] {
+					walk(elem, fieldIndex)
+				}
+				continue
+			}
+
+			if _, ok := reflectType.FieldByName(field.Name); ok {
+				continue
+			}
+			position, ok := positions[field.Name]
+			if !ok {
+				position = len(fields)
+				positions[field.Name] = position
+				fields = append(fields, ambiguousField{name: field.Name})
+			}
+
+			// only the shallowest fields are ambiguous, the deeper ones are shadowed
+			if indexes := fields[position].indexes; len(indexes) == 0 || len(fieldIndex) < len(indexes[0]) {
+				fields[position].indexes = [][]int{fieldIndex}
+			} else if len(fieldIndex) == len(indexes[0]) {
+				fields[position].indexes = append(indexes, fieldIndex)
+			}
+		}
+		delete(embedding, t)
+	}
+	walk(reflectType, nil)
+	return fields
+}
+
 func indirect(reflectValue reflect.Value) reflect.Value {
 	for reflectValue.Kind() == reflect.Ptr {
 		reflectValue = reflectValue.Elem()
@@ -346,24 +623,335 @@ func indirectType(reflectType reflect.Type) (_ reflect.Type, isPtr bool) {
 	return reflectType, isPtr
 }
 
-func set(to, from reflect.Value, deepCopy bool) bool {
+// copyValue copies from into to, falling back to a recursive copy for the values
+// set can't assign directly, such as structs or deep copied maps and slices
+func copyValue(to, from reflect.Value, opt Option) error {
+	copied, err := set(to, from, opt)
+	if err != nil || copied {
+		return err
+	}
+
+	for to.Kind() == reflect.Ptr {
+		if to.IsNil() {
+			to.Set(reflect.New(to.Type().Elem()))
+		}
+		to = to.Elem()
+	}
+
+	if from = indirect(from); !from.IsValid() {
+		return nil
+	}
+	return copier(to.Addr().Interface(), from.Interface(), opt)
+}
+
+// mapKeyCompatible reports whether keys of type from can be copied into keys of type to
+func mapKeyCompatible(from, to reflect.Type, opt Option) bool {
+	if from.ConvertibleTo(to) {
+		return true
+	}
+
+	if _, ok := opt.converter(from, to); ok {
+		return true
+	}
+
+	if opt.convertible(to, from) {
+		return true
+	}
+
+	from, _ = indirectType(from)
+	to, _ = indirectType(to)
+	return from.ConvertibleTo(to) || (from.Kind() == reflect.Struct && to.Kind() == reflect.Struct)
+}
+
+// pairFields returns the names of the fields tagged with `key` and `value` in a key/value pair struct
+func pairFields(pairType reflect.Type) (keyName, valueName string, ok bool) {
+	for name, flags := range getBitFlags(pairType) {
+		if flags&tagKey != 0 {
+			keyName = name
+		}
+		if flags&tagValue != 0 {
+			valueName = name
+		}
+	}
+	return keyName, valueName, keyName != "" && valueName != ""
+}
+
+// sortedMapKeys returns the keys of a map, sorted when they are of an ordered kind so
+// copying a map into a slice is deterministic
+func sortedMapKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		switch a.Kind() {
+		case reflect.String:
+			return a.String() < b.String()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		}
+		return false
+	})
+	return keys
+}
+
+// converter returns the converter registered for the given pair of types
+func (opt Option) converter(from, to reflect.Type) (TypeConverter, bool) {
+	for _, cnv := range opt.Converters {
+		if reflect.TypeOf(cnv.SrcType) == from && reflect.TypeOf(cnv.DstType) == to {
+			return cnv, true
+		}
+	}
+	return TypeConverter{}, false
+}
+
+// convertible reports whether values of type from are converted into values of type to by a
+// registered enum table, by unwrapping or wrapping Nullable values, or by the TextMarshaling,
+// Times or Strconv options
+func (opt Option) convertible(to, from reflect.Type) bool {
+	return enumOf(to, from) != nil || nullableConvertible(to, from, opt) || (opt.TextMarshaling && textConvertible(to, from)) ||
+		(opt.Times && timeConvertible(to, from)) || (opt.Strconv && numberConvertible(to, from))
+}
+
+// concurrent reports whether the n elements of a slice are copied by workers
+func (opt Option) concurrent(n int) bool {
+	return opt.Workers > 1 && n > 1
+}
+
+// sequential returns the options to copy an element of a slice, the workers are only used for
+// the outermost slice
+func (opt Option) sequential() Option {
+	opt.Workers = 0
+	return opt
+}
+
+// forEach calls fn with the indexes from 0 to n-1, stopping at the first error, and returns the
+// index which failed with its error. When the elements are copied concurrently, the error of the
+// lowest index is returned, and panics of the workers are raised again on the calling goroutine
+func forEach(n int, opt Option, fn func(i int) error) (int, error) {
+	if !opt.concurrent(n) {
+		for i := 0; i < n; i++ {
+			if err := fn(i); err != nil {
+				return i, err
+			}
+		}
+		return n, nil
+	}
+
+	workers := opt.Workers
+	if workers > n {
+		workers = n
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		next     = int64(-1)
+		failed   = int64(n) // lowest index which failed, workers stop past it
+		firstErr error
+		panicked interface{}
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					mu.Lock()
+					if panicked == nil {
+						panicked = r
+					}
+					atomic.StoreInt64(&failed, -1)
+					mu.Unlock()
+				}
+			}()
+
+			for {
+				i := atomic.AddInt64(&next, 1)
+				if i >= int64(n) || i >= atomic.LoadInt64(&failed) {
+					return
+				}
+				if err := fn(int(i)); err != nil {
+					mu.Lock()
+					if i < atomic.LoadInt64(&failed) {
+						atomic.StoreInt64(&failed, i)
+						firstErr = err
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if panicked != nil {
+		panic(panicked)
+	}
+	return int(failed), firstErr
+}
+
+// context returns the context of the copy
+func (opt Option) context() context.Context {
+	if opt.ctx == nil {
+		return context.Background()
+	}
+	return opt.ctx
+}
+
+// at returns the options to copy the value at node, the path is only tracked for OnScanError
+func (opt Option) at(node pathNode) Option {
+	if opt.OnScanError != nil {
+		n := node
+		n.parent = opt.path
+		opt.path = &n
+	}
+	return opt
+}
+
+// cloning returns the options to clone a value of type t, unless a value is already cloned
+func (opt Option) cloning(t reflect.Type) Option {
+	if opt.DeepCopy && opt.CopyUnexported && opt.cloned == nil {
+		opt.cloned = t
+	}
+	return opt
+}
+
+// clones reports whether the unexported fields of the struct type t are cloned, the types of
+// other packages than the cloned value, such as time.Time, are copied as opaque values
+func (opt Option) clones(t reflect.Type) bool {
+	return opt.cloned == nil || t.Name() == "" || t.PkgPath() == packageOf(opt.cloned)
+}
+
+// scanError returns err, the error of a Scan or Value method, as a *PathError, or reports it
+// to OnScanError and skips the value
+func scanError(err error, opt Option) (bool, error) {
+	pathErr := &PathError{Err: err}
+	if opt.OnScanError == nil {
+		return false, pathErr
+	}
+	pathErr.Path = opt.path.String()
+	opt.OnScanError(pathErr)
+	return true, nil
+}
+
+// done returns a *PathError wrapping the context error when the context of the copy is done
+func (opt Option) done() error {
+	if opt.ctx == nil {
+		return nil
+	}
+	if err := opt.ctx.Err(); err != nil {
+		return &PathError{Err: err}
+	}
+	return nil
+}
+
+// convert sets `to` with a converter if there is one for the types of `to` and `from`
+func convert(to, from reflect.Value, opt Option) (bool, error) {
+	if len(opt.Converters) == 0 || !from.IsValid() {
+		return false, nil
+	}
+
+	cnv, ok := opt.converter(from.Type(), to.Type())
+	if !ok {
+		return false, nil
+	}
+
+	var (
+		result interface{}
+		err    error
+	)
+	if cnv.FnContext != nil {
+		result, err = cnv.FnContext(opt.context(), from.Interface())
+	} else {
+		result, err = cnv.Fn(from.Interface())
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if result == nil {
+		to.Set(reflect.Zero(to.Type()))
+	} else {
+		to.Set(reflect.ValueOf(result))
+	}
+	return true, nil
+}
+
+// setUncopyable applies the uncopyable policy, it reports false when `from` should be
+// assigned as usual
+func setUncopyable(to, from reflect.Value, opt Option) (bool, error) {
+	if from.Kind() == reflect.Interface {
+		from = from.Elem()
+	}
+
+	if kind := from.Kind(); (kind != reflect.Chan && kind != reflect.Func) || from.IsNil() {
+		return false, nil
+	}
+
+	switch opt.Uncopyable {
+	case UncopyableSkip:
+		return true, nil
+	case UncopyableZero:
+		to.Set(reflect.Zero(to.Type()))
+		return true, nil
+	case UncopyableError:
+		return false, fmt.Errorf("%w: %v", ErrUncopyable, from.Type())
+	}
+	return false, nil
+}
+
+func set(to, from reflect.Value, opt Option) (bool, error) {
 	if from.IsValid() {
+		if ok, err := convert(to, from, opt); ok || err != nil {
+			return ok, err
+		}
+
+		if ok, err := setEnum(to, from); ok || err != nil {
+			return ok, err
+		}
+
+		if ok, err := setUncopyable(to, from, opt); ok || err != nil {
+			return ok, err
+		}
+
+		if ok, err := setNullable(to, from, opt); ok || err != nil {
+			return ok, err
+		}
+
+		if ok, err := setTime(to, from, opt); ok || err != nil {
+			return ok, err
+		}
+
+		if ok, err := setText(to, from, opt); ok || err != nil {
+			return ok, err
+		}
+
+		if ok, err := setNumber(to, from, opt); ok || err != nil {
+			return ok, err
+		}
+
+		if ok, err := setIntString(to, from, opt); ok || err != nil {
+			return ok, err
+		}
+
 		if to.Kind() == reflect.Ptr {
 			// set `to` to nil if from is nil
 			if from.Kind() == reflect.Ptr && from.IsNil() {
 				to.Set(reflect.Zero(to.Type()))
-				return true
+				return true, nil
 			} else if to.IsNil() {
 				// `from`         -> `to`
 				// sql.NullString -> *string
 				if fromValuer, ok := driverValuer(from); ok {
 					v, err := fromValuer.Value()
 					if err != nil {
-						return false
+						return scanError(fmt.Errorf("value of %v: %w", from.Type(), err), opt)
 					}
 					// if `from` is not valid do nothing with `to`
 					if v == nil {
-						return true
+						return true, nil
 					}
 				}
 				// allocate new `to` variable with default value (eg. *string -> new(string))
@@ -371,28 +959,38 @@ func set(to, from reflect.Value, deepCopy bool) bool {
 			}
 			// depointer `to`
 			to = to.Elem()
+
+			if ok, err := convert(to, from, opt); ok || err != nil {
+				return ok, err
+			}
 		}
 
-		if deepCopy {
+		if opt.DeepCopy {
 			toKind := to.Kind()
 			if toKind == reflect.Interface && to.IsNil() {
+				if reflect.TypeOf(from.Interface()) == nil {
+					return true, nil
+				}
 				to.Set(reflect.New(reflect.TypeOf(from.Interface())).Elem())
 				toKind = reflect.TypeOf(to.Interface()).Kind()
 			}
 			if toKind == reflect.Struct || toKind == reflect.Map || toKind == reflect.Slice {
-				return false
+				return false, nil
 			}
 		}
 
 		if from.Type().ConvertibleTo(to.Type()) {
 			to.Set(from.Convert(to.Type()))
+		} else if from.Kind() == reflect.Ptr && from.Type().Elem().ConvertibleTo(to.Type()) {
+			// *T -> T, without going through Scan
+			return set(to, from.Elem(), opt)
 		} else if toScanner, ok := to.Addr().Interface().(sql.Scanner); ok {
 			// `from`  -> `to`
 			// *string -> sql.NullString
 			if from.Kind() == reflect.Ptr {
 				// if `from` is nil do nothing with `to`
 				if from.IsNil() {
-					return true
+					return true, nil
 				}
 				// depointer `from`
 				from = indirect(from)
@@ -402,31 +1000,31 @@ func set(to, from reflect.Value, deepCopy bool) bool {
 			// set `to` by invoking method Scan(`from`)
 			err := toScanner.Scan(from.Interface())
 			if err != nil {
-				return false
+				return scanError(fmt.Errorf("scan %v into %v: %w", from.Type(), to.Type(), err), opt)
 			}
 		} else if fromValuer, ok := driverValuer(from); ok {
 			// `from`         -> `to`
 			// sql.NullString -> string
 			v, err := fromValuer.Value()
 			if err != nil {
-				return false
+				return scanError(fmt.Errorf("value of %v: %w", from.Type(), err), opt)
 			}
 			// if `from` is not valid do nothing with `to`
 			if v == nil {
-				return true
+				return true, nil
 			}
 			rv := reflect.ValueOf(v)
 			if rv.Type().AssignableTo(to.Type()) {
 				to.Set(rv)
 			}
 		} else if from.Kind() == reflect.Ptr {
-			return set(to, from.Elem(), deepCopy)
+			return set(to, from.Elem(), opt)
 		} else {
-			return false
+			return false, nil
 		}
 	}
 
-	return true
+	return true, nil
 }
 
 // parseTags Parses struct tags and returns uint8 bit flags.
@@ -440,11 +1038,26 @@ func parseTags(tag string) (flags uint8) {
 			flags = flags | tagMust
 		case "nopanic":
 			flags = flags | tagNoPanic
+		case "key":
+			flags = flags | tagKey
+		case "value":
+			flags = flags | tagValue
 		}
 	}
 	return
 }
 
+// tagPath returns the field path of a tag, the first value which is not a flag, such as
+// Customer.Name in `copier:"Customer.Name,must"`
+func tagPath(tag string) string {
+	for _, t := range strings.Split(tag, ",") {
+		if t != "" && parseTags(t) == 0 {
+			return t
+		}
+	}
+	return ""
+}
+
 // getBitFlags Parses struct tags for bit flags.
 func getBitFlags(toType reflect.Type) map[string]uint8 {
 	flags := map[string]uint8{}
@@ -23,6 +23,37 @@ func BenchmarkCopyStructFields(b *testing.B) {
 	}
 }
 
+func BenchmarkCopySlice(b *testing.B) {
+	var fakeAge int32 = 12
+	users := make([]User, 10000)
+	for i := range users {
+		users[i] = User{Name: "Jinzhu", Nickname: "jinzhu", Age: 18, FakeAge: &fakeAge, Role: "Admin", Notes: []string{"hello world", "welcome"}}
+	}
+
+	b.Run("sequential", func(b *testing.B) {
+		for x := 0; x < b.N; x++ {
+			var employees []Employee
+			copier.Copy(&employees, &users)
+		}
+	})
+
+	b.Run("workers", func(b *testing.B) {
+		for x := 0; x < b.N; x++ {
+			var employees []Employee
+			copier.CopyWithOption(&employees, &users, copier.Option{Workers: 8})
+		}
+	})
+}
+
+func BenchmarkMapper(b *testing.B) {
+	var fakeAge int32 = 12
+	user := User{Name: "Jinzhu", Nickname: "jinzhu", Age: 18, FakeAge: &fakeAge, Role: "Admin", Notes: []string{"hello world", "welcome"}, flags: []byte{'x'}}
+	mapper, _ := copier.NewMapper(User{}, Employee{})
+	for x := 0; x < b.N; x++ {
+		mapper.Map(&Employee{}, &user)
+	}
+}
+
 func BenchmarkNamaCopy(b *testing.B) {
 	var fakeAge int32 = 12
 	user := User{Name: "Jinzhu", Nickname: "jinzhu", Age: 18, FakeAge: &fakeAge, Role: "Admin", Notes: []string{"hello world", "welcome"}, flags: []byte{'x'}}
@@ -0,0 +1,117 @@
+package copier_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/jinzhu/copier"
+)
+
+type OrderItem struct {
+	Price int
+}
+
+type OrderItemRow struct {
+	Price string
+}
+
+type Cart struct {
+	Items []OrderItem
+}
+
+type CartRow struct {
+	Items []OrderItemRow
+}
+
+type cartKey struct{}
+
+func TestCopyContext(t *testing.T) {
+	cart := Cart{Items: []OrderItem{{Price: 1}, {Price: 2}, {Price: 3}}}
+
+	t.Run("Should copy with a live context", func(t *testing.T) {
+		ctx := context.WithValue(context.Background(), cartKey{}, "$")
+		converter := copier.TypeConverter{
+			SrcType: 0,
+			DstType: "",
+			FnContext: func(ctx context.Context, src interface{}) (interface{}, error) {
+				return ctx.Value(cartKey{}).(string) + strconv.Itoa(src.(int)), nil
+			},
+		}
+
+		row := CartRow{}
+		if err := copier.CopyContext(ctx, &row, &cart, copier.Option{Converters: []copier.TypeConverter{converter}}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(row.Items) != 3 || row.Items[2].Price != "$3" {
+			t.Errorf("Converters should get the context, got %#v", row)
+		}
+	})
+
+	t.Run("Should not copy with a done context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		row := CartRow{}
+		err := copier.CopyContext(ctx, &row, &cart, copier.Option{})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Should return the context error, got %v", err)
+		}
+		if row.Items != nil {
+			t.Errorf("Should not copy anything, got %#v", row)
+		}
+	})
+
+	t.Run("Should stop where the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		converter := copier.TypeConverter{
+			SrcType: 0,
+			DstType: "",
+			FnContext: func(ctx context.Context, src interface{}) (interface{}, error) {
+				if src.(int) == 2 {
+					cancel()
+				}
+				return strconv.Itoa(src.(int)), nil
+			},
+		}
+
+		row := CartRow{}
+		err := copier.CopyContext(ctx, &row, &cart, copier.Option{Converters: []copier.TypeConverter{converter}})
+		var pathErr *copier.PathError
+		if !errors.As(err, &pathErr) || !errors.Is(err, context.Canceled) {
+			t.Fatalf("Should return a path error wrapping the context error, got %v", err)
+		}
+		if pathErr.Path != "Items[2]" || err.Error() != "Items[2]: context canceled" {
+			t.Errorf("Should report where the copy stopped, got %v", err)
+		}
+		if len(row.Items) != 2 || row.Items[1].Price != "2" {
+			t.Errorf("Elements before the cancellation should be copied, got %#v", row)
+		}
+	})
+
+	t.Run("Should check the context between map entries", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		calls := 0
+		converter := copier.TypeConverter{
+			SrcType: OrderItem{},
+			DstType: OrderItemRow{},
+			FnContext: func(ctx context.Context, src interface{}) (interface{}, error) {
+				calls++
+				cancel()
+				return OrderItemRow{}, nil
+			},
+		}
+
+		to := map[string]OrderItemRow{}
+		from := map[string]OrderItem{"a": {Price: 1}, "b": {Price: 2}}
+		err := copier.CopyContext(ctx, &to, &from, copier.Option{Converters: []copier.TypeConverter{converter}})
+		if !errors.Is(err, context.Canceled) || calls != 1 || len(to) != 1 {
+			t.Errorf("Should stop after the first entry, got %v, %v calls, %v", err, calls, to)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package copier_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/jinzhu/copier"
@@ -141,3 +142,64 @@ func checkType2WithType4(t2 TypeStruct2, t4 TypeStruct4, t *testing.T, testCase
 		t.Errorf("%v: type struct 4 and type struct 2 is not equal", testCase)
 	}
 }
+
+type AgeStruct struct {
+	Age    int
+	Code   uint8
+	MaxAge *int64
+}
+
+type AgeStringStruct struct {
+	Age    string
+	Code   string
+	MaxAge string
+}
+
+func TestCopyIntToString(t *testing.T) {
+	maxAge := int64(120)
+	from := AgeStruct{Age: 65, Code: 66, MaxAge: &maxAge}
+
+	t.Run("Should copy integers in base 10 by default", func(t *testing.T) {
+		var to AgeStringStruct
+		if err := copier.Copy(&to, &from); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if to != (AgeStringStruct{Age: "65", Code: "66", MaxAge: "120"}) {
+			t.Errorf("Integers should be copied in base 10, got %+v", to)
+		}
+
+		var s string
+		if err := copier.Copy(&s, 65); err != nil || s != "65" {
+			t.Errorf("Should copy top level integers, got %q, %v", s, err)
+		}
+
+		var strs []string
+		if err := copier.Copy(&strs, []int{1, 22}); err != nil || len(strs) != 2 || strs[0] != "1" || strs[1] != "22" {
+			t.Errorf("Should copy slices of integers, got %q, %v", strs, err)
+		}
+	})
+
+	t.Run("Should copy integers as runes", func(t *testing.T) {
+		var to AgeStringStruct
+		if err := copier.CopyWithOption(&to, &from, copier.Option{IntToString: copier.IntToStringRune}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if to != (AgeStringStruct{Age: "A", Code: "B", MaxAge: "x"}) {
+			t.Errorf("Integers should be copied as runes, got %+v", to)
+		}
+	})
+
+	t.Run("Should fail on integers into strings", func(t *testing.T) {
+		var to AgeStringStruct
+		err := copier.CopyWithOption(&to, &from, copier.Option{IntToString: copier.IntToStringError})
+
+		var pathErr *copier.PathError
+		if !errors.As(err, &pathErr) || pathErr.Path != "Age" || !errors.Is(err, copier.ErrNotSupported) {
+			t.Fatalf("Should get ErrNotSupported at Age, got %v", err)
+		}
+
+		if _, err := copier.NewMapperWithOption(AgeStruct{}, AgeStringStruct{}, copier.Option{IntToString: copier.IntToStringError}); err == nil {
+			t.Errorf("Mapper should reject integers into strings")
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package copier_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jinzhu/copier"
+)
+
+type Status string
+
+type StatusCode int
+
+func init() {
+	copier.RegisterEnum(map[Status]StatusCode{
+		"pending":  0,
+		"active":   1,
+		"disabled": 9,
+	})
+}
+
+type Subscription struct {
+	Status  Status
+	Pending *Status
+	History []Status
+	Counts  map[Status]int
+}
+
+type SubscriptionRow struct {
+	Status  StatusCode
+	Pending StatusCode
+	History []StatusCode
+	Counts  map[StatusCode]int
+}
+
+func TestRegisterEnum(t *testing.T) {
+	pending := Status("pending")
+	subscription := Subscription{
+		Status:  "disabled",
+		Pending: &pending,
+		History: []Status{"pending", "active", "disabled"},
+		Counts:  map[Status]int{"active": 3},
+	}
+	row := SubscriptionRow{
+		Status:  9,
+		Pending: 0,
+		History: []StatusCode{0, 1, 9},
+		Counts:  map[StatusCode]int{1: 3},
+	}
+
+	t.Run("Should copy values with the table", func(t *testing.T) {
+		var to SubscriptionRow
+		if err := copier.Copy(&to, &subscription); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(to, row) {
+			t.Errorf("Enums should be copied with the table, got %+v", to)
+		}
+	})
+
+	t.Run("Should copy values with the inverse table", func(t *testing.T) {
+		var to Subscription
+		if err := copier.Copy(&to, &row); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(to, subscription) {
+			t.Errorf("Enums should be copied with the inverse table, got %+v", to)
+		}
+	})
+
+	t.Run("Should fail on unknown values", func(t *testing.T) {
+		var to SubscriptionRow
+		err := copier.Copy(&to, &Subscription{Status: "active", History: []Status{"active", "deleted"}})
+
+		var pathErr *copier.PathError
+		if !errors.As(err, &pathErr) || pathErr.Path != "History[1]" || !errors.Is(err, copier.ErrUnknownEnum) {
+			t.Fatalf("Should get ErrUnknownEnum at History[1], got %v", err)
+		}
+
+		counts := map[StatusCode]int{}
+		err = copier.Copy(&counts, map[Status]int{"deleted": 1})
+		if !errors.As(err, &pathErr) || pathErr.Path != "[deleted]" || !errors.Is(err, copier.ErrUnknownEnum) {
+			t.Errorf("Should get ErrUnknownEnum at [deleted], got %v", err)
+		}
+
+		var status Status
+		if err := copier.Copy(&status, StatusCode(5)); !errors.Is(err, copier.ErrUnknownEnum) {
+			t.Errorf("Should get ErrUnknownEnum, got %v", err)
+		}
+	})
+
+	t.Run("Should be copyable with mappers", func(t *testing.T) {
+		opt := copier.Option{IntToString: copier.IntToStringError}
+		if _, err := copier.NewMapperWithOption(SubscriptionRow{}, Subscription{}, opt); err != nil {
+			t.Errorf("Enums should be copyable, got %v", err)
+		}
+	})
+
+	t.Run("Should panic on tables that can't be inverted", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("RegisterEnum should panic")
+			}
+		}()
+		copier.RegisterEnum(map[string]int{"a": 1, "b": 1})
+	})
+}
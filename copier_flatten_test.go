@@ -0,0 +1,229 @@
+package copier_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jinzhu/copier"
+)
+
+type Address struct {
+	City string
+}
+
+type Customer struct {
+	Name    string
+	Address *Address
+}
+
+type Order struct {
+	ID       int
+	Customer Customer
+}
+
+type OrderRow struct {
+	ID                  int
+	CustomerName        string
+	CustomerAddressCity string
+}
+
+type OrderRowSeparated struct {
+	ID                    int
+	Customer_Name         string
+	Customer_Address_City string
+}
+
+type OrderRowTagged struct {
+	ID     int
+	Client string `copier:"Customer.Name"`
+	City   string `copier:"Customer.Address.City,must"`
+}
+
+func TestFlatten(t *testing.T) {
+	order := Order{ID: 1, Customer: Customer{Name: "Jinzhu", Address: &Address{City: "Shanghai"}}}
+
+	row := OrderRow{}
+	if err := copier.CopyWithOption(&row, &order, copier.Option{Flatten: true}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if row.ID != 1 || row.CustomerName != "Jinzhu" || row.CustomerAddressCity != "Shanghai" {
+		t.Errorf("Nested fields should be flattened, got %#v", row)
+	}
+
+	separated := OrderRowSeparated{}
+	if err := copier.CopyWithOption(&separated, &order, copier.Option{Flatten: true, FlattenSeparator: "_"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if separated.Customer_Name != "Jinzhu" || separated.Customer_Address_City != "Shanghai" {
+		t.Errorf("Nested fields should be flattened with separator, got %#v", separated)
+	}
+
+	withoutOption := OrderRow{}
+	copier.Copy(&withoutOption, &order)
+	if withoutOption.CustomerName != "" {
+		t.Errorf("Nested fields should not be flattened without option")
+	}
+
+	noAddress := OrderRow{}
+	if err := copier.CopyWithOption(&noAddress, &Order{Customer: Customer{Name: "Jinzhu"}}, copier.Option{Flatten: true}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if noAddress.CustomerName != "Jinzhu" || noAddress.CustomerAddressCity != "" {
+		t.Errorf("Nil nested pointers should be skipped, got %#v", noAddress)
+	}
+}
+
+func TestUnflatten(t *testing.T) {
+	row := OrderRow{ID: 1, CustomerName: "Jinzhu", CustomerAddressCity: "Shanghai"}
+
+	order := Order{}
+	if err := copier.CopyWithOption(&order, &row, copier.Option{Flatten: true}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if order.ID != 1 || order.Customer.Name != "Jinzhu" || order.Customer.Address == nil || order.Customer.Address.City != "Shanghai" {
+		t.Errorf("Flat fields should be unflattened, got %#v", order)
+	}
+
+	plan, err := copier.PlanWithOption(&order, &row, copier.Option{Flatten: true, Strict: copier.StrictTo | copier.StrictFrom})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if plan.UnmatchedTo != nil || plan.UnmatchedFrom != nil {
+		t.Errorf("Nested fields should be matched, got %v, %v", plan.UnmatchedTo, plan.UnmatchedFrom)
+	}
+}
+
+func TestPathTags(t *testing.T) {
+	order := Order{ID: 1, Customer: Customer{Name: "Jinzhu", Address: &Address{City: "Shanghai"}}}
+
+	row := OrderRowTagged{}
+	if err := copier.Copy(&row, &order); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if row.ID != 1 || row.Client != "Jinzhu" || row.City != "Shanghai" {
+		t.Errorf("Tagged paths should be copied, got %#v", row)
+	}
+
+	back := Order{}
+	if err := copier.Copy(&back, &row); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if back.Customer.Name != "Jinzhu" || back.Customer.Address == nil || back.Customer.Address.City != "Shanghai" {
+		t.Errorf("Tagged paths should build nested values, got %#v", back)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Expected a panic for must path with nil intermediate.")
+		}
+	}()
+	copier.Copy(&OrderRowTagged{}, &Order{})
+}
+
+func TestPathTagsSameType(t *testing.T) {
+	row := OrderRowTagged{ID: 1, Client: "Jinzhu", City: "Shanghai"}
+
+	copied := OrderRowTagged{}
+	if err := copier.Copy(&copied, &row); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if copied != row {
+		t.Errorf("Tagged fields should be copied by name into the same type, got %#v", copied)
+	}
+
+	clone, err := copier.Clone(row)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if clone != row {
+		t.Errorf("Tagged fields should be cloned, got %#v", clone)
+	}
+
+	if _, err := copier.NewMapper(OrderRowTagged{}, OrderRowTagged{}); err != nil {
+		t.Errorf("Tagged fields should be mapped by name into the same type, got %v", err)
+	}
+}
+
+func TestPathTagsMapper(t *testing.T) {
+	mapper, err := copier.NewMapper(Order{}, OrderRowTagged{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, field := range mapper.Fields() {
+		if field.To == "City" && field.From != "Customer.Address.City" {
+			t.Errorf("Mapping table should list the source path, got %+v", field)
+		}
+	}
+
+	type BadPath struct {
+		City string `copier:"Customer.Adress.City"`
+	}
+	if _, err := copier.NewMapper(Order{}, BadPath{}); err == nil {
+		t.Errorf("Should report path not found")
+	}
+}
+
+type Profile struct {
+	Addresses []*Address
+	Meta      map[string]string
+	Scores    map[int]int
+}
+
+type Account struct {
+	Profile *Profile
+}
+
+type AccountRow struct {
+	City     string  `copier:"Profile.Addresses[1].City"`
+	Region   *string `copier:"Profile.Meta[region]"`
+	TopScore int     `copier:"Profile.Scores[1],must,nopanic"`
+}
+
+func TestPathTagsThroughSlicesAndMaps(t *testing.T) {
+	account := Account{Profile: &Profile{
+		Addresses: []*Address{{City: "Hangzhou"}, {City: "Shanghai"}},
+		Meta:      map[string]string{"region": "east"},
+		Scores:    map[int]int{1: 99},
+	}}
+
+	row := AccountRow{}
+	if err := copier.Copy(&row, &account); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if row.City != "Shanghai" || row.Region == nil || *row.Region != "east" || row.TopScore != 99 {
+		t.Errorf("Paths should be resolved through slices and maps, got %#v", row)
+	}
+
+	for _, account := range []Account{
+		{},
+		{Profile: &Profile{}},
+		{Profile: &Profile{Addresses: []*Address{{}, nil}, Scores: map[int]int{2: 1}}},
+	} {
+		row := AccountRow{}
+		if err := copier.Copy(&row, &account); err == nil {
+			t.Errorf("Missing values should fail the must check, got %#v", row)
+		}
+		if row.City != "" || row.Region != nil {
+			t.Errorf("Missing values should be skipped, got %#v", row)
+		}
+	}
+
+	plan, err := copier.Plan(&row, &account)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(plan.Fields) != 3 || plan.Fields[1].From != "Profile.Meta[region]" {
+		t.Errorf("Plan should list the source paths, got %+v", plan.Fields)
+	}
+	if len(plan.UnmatchedFrom) != 0 {
+		t.Errorf("Profile should count as copied, got %v", plan.UnmatchedFrom)
+	}
+
+	for _, path := range []string{"Profile.Addresses[x].City", "Profile.Meta[region", "Profile.Scores[a]", "Profile[0]", "Profile.Addresses[0]City"} {
+		typ := reflect.StructOf([]reflect.StructField{{Name: "City", Type: reflect.TypeOf(""), Tag: reflect.StructTag(`copier:"` + path + `"`)}})
+		if _, err := copier.NewMapper(Account{}, reflect.New(typ).Interface()); err == nil {
+			t.Errorf("Path %q should be reported as not found", path)
+		}
+	}
+}
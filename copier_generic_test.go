@@ -0,0 +1,89 @@
+package copier_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jinzhu/copier"
+)
+
+func TestClone(t *testing.T) {
+	user := User{Name: "Jinzhu", Notes: []string{"hello"}, flags: []byte{'x'}}
+
+	clone, err := copier.Clone(user)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if clone.Name != user.Name || string(clone.flags) != "x" || len(clone.Notes) != 1 {
+		t.Errorf("Should be cloned, got %#v", clone)
+	}
+
+	user.Notes[0] = "world"
+	if clone.Notes[0] != "hello" {
+		t.Errorf("Should be deep copied")
+	}
+
+	ptrClone, err := copier.Clone(&user)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ptrClone == &user || ptrClone.Name != user.Name {
+		t.Errorf("Pointer should be cloned, got %#v", ptrClone)
+	}
+
+	var nilUser *User
+	if nilClone, err := copier.Clone(nilUser); err != nil || nilClone != nil {
+		t.Errorf("Nil should be cloned as nil, got %v, %v", nilClone, err)
+	}
+
+	type Event struct {
+		Name string
+		At   time.Time
+	}
+	event := Event{Name: "launch", At: time.Date(2021, 1, 2, 3, 4, 5, 0, time.FixedZone("JST", 9*60*60))}
+	eventClone, err := copier.Clone(event)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if eventClone.At.String() != event.At.String() || eventClone.At.Location() != event.At.Location() {
+		t.Errorf("Times should be cloned with their locations, got %v", eventClone.At)
+	}
+}
+
+func TestMap(t *testing.T) {
+	user := User{Name: "Jinzhu", Nickname: "jinzhu", Age: 18, Role: "Admin", Notes: []string{"hello world"}}
+
+	employee, err := copier.Map[User, Employee](user)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	checkEmployee(employee, user, t, "Map To Struct")
+
+	employeePtr, err := copier.Map[*User, *Employee](&user)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	checkEmployee(*employeePtr, user, t, "Map To Ptr")
+
+	if age, err := copier.Map[int32, int64](18); err != nil || age != 18 {
+		t.Errorf("Should map basic types, got %v, %v", age, err)
+	}
+}
+
+func TestMapSlice(t *testing.T) {
+	users := []User{{Name: "Jinzhu", Age: 18, Role: "Admin", Notes: []string{"hello world"}}, {Name: "Jinzhu2", Age: 22, Role: "Dev"}}
+
+	employees, err := copier.MapSlice[User, Employee](users)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(employees) != 2 {
+		t.Fatalf("Should have two elems, got %v", len(employees))
+	}
+	checkEmployee(employees[0], users[0], t, "MapSlice @ 1")
+	checkEmployee(employees[1], users[1], t, "MapSlice @ 2")
+
+	if employees, err := copier.MapSlice[User, Employee](nil); err != nil || employees != nil {
+		t.Errorf("Nil slice should map to nil, got %v, %v", employees, err)
+	}
+}
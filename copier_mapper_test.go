@@ -0,0 +1,108 @@
+package copier_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jinzhu/copier"
+)
+
+func TestMapper(t *testing.T) {
+	mapper, err := copier.NewMapper(User{}, Employee{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var fakeAge int32 = 12
+	user := User{Name: "Jinzhu", Nickname: "jinzhu", Age: 18, FakeAge: &fakeAge, Role: "Admin", Notes: []string{"hello world"}}
+
+	employee := Employee{}
+	if err := mapper.Map(&employee, &user); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	checkEmployee(employee, user, t, "Mapper From Ptr")
+
+	employee2 := &Employee{}
+	if err := mapper.Map(&employee2, user); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	checkEmployee(*employee2, user, t, "Mapper From Struct To Double Ptr")
+
+	if err := mapper.Map(&User{}, &user); !errors.Is(err, copier.ErrInvalidCopyDestination) {
+		t.Errorf("Should get ErrInvalidCopyDestination, got %v", err)
+	}
+	if err := mapper.Map(&employee, &employee); !errors.Is(err, copier.ErrInvalidCopyFrom) {
+		t.Errorf("Should get ErrInvalidCopyFrom, got %v", err)
+	}
+}
+
+func TestMapperFields(t *testing.T) {
+	mapper, err := copier.NewMapper(&User{}, &Employee{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := map[string]copier.MappingKind{
+		"Name":      copier.MappingField,
+		"Birthday":  copier.MappingField,
+		"Nickname":  copier.MappingField,
+		"Role":      copier.MappingSetter,
+		"Age":       copier.MappingField,
+		"FakeAge":   copier.MappingField,
+		"Notes":     copier.MappingField,
+		"DoubleAge": copier.MappingGetter,
+	}
+
+	fields := mapper.Fields()
+	if len(fields) != len(expected) {
+		t.Errorf("Should have %v mappings, got %v", len(expected), fields)
+	}
+	for _, field := range fields {
+		if kind, ok := expected[field.To]; !ok || kind != field.Kind || field.From != field.To {
+			t.Errorf("Unexpected mapping %+v", field)
+		}
+	}
+
+	if table := mapper.String(); !strings.Contains(table, "DoubleAge <- DoubleAge (getter)") {
+		t.Errorf("Mapping table should list the getters, got %v", table)
+	}
+}
+
+func TestMapperValidation(t *testing.T) {
+	t.Run("Should report unknown tags", func(t *testing.T) {
+		type To struct {
+			Name string `copier:"mustt"`
+		}
+		_, err := copier.NewMapper(User{}, To{})
+		if !errors.Is(err, copier.ErrInvalidMapping) || !strings.Contains(err.Error(), `unknown tag "mustt"`) {
+			t.Errorf("Should report unknown tag, got %v", err)
+		}
+	})
+
+	t.Run("Should report must fields without source", func(t *testing.T) {
+		_, err := copier.NewMapper(User2{}, EmployeeTags{})
+		if !errors.Is(err, copier.ErrInvalidMapping) || !strings.Contains(err.Error(), "field Name has must tag") {
+			t.Errorf("Should report must field, got %v", err)
+		}
+	})
+
+	t.Run("Should report unconvertible fields", func(t *testing.T) {
+		_, err := copier.NewMapper(structSameName1{}, structSameName2{})
+		if !errors.Is(err, copier.ErrInvalidMapping) || !strings.Contains(err.Error(), "field B") || !strings.Contains(err.Error(), "field C") {
+			t.Errorf("Should report unconvertible fields, got %v", err)
+		}
+	})
+
+	t.Run("Should accept valid tags", func(t *testing.T) {
+		if _, err := copier.NewMapper(User1{}, EmployeeTags{}); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Should only map structs", func(t *testing.T) {
+		if _, err := copier.NewMapper(1, Employee{}); !errors.Is(err, copier.ErrNotSupported) {
+			t.Errorf("Should get ErrNotSupported, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,151 @@
+package copier_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jinzhu/copier"
+)
+
+type Optional[T any] struct {
+	value T
+	valid bool
+}
+
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{value: value, valid: true}
+}
+
+func (o Optional[T]) Valid() bool { return o.valid }
+func (o Optional[T]) Get() T      { return o.value }
+func (o *Optional[T]) Set(value T) {
+	o.value, o.valid = value, true
+}
+
+var _ copier.NullableSetter[string] = (*Optional[string])(nil)
+
+// Percent only accepts values up to 100
+type Percent struct {
+	value int
+	valid bool
+}
+
+func (p Percent) Valid() bool { return p.valid }
+func (p Percent) Get() int    { return p.value }
+func (p *Percent) Set(value int) error {
+	if value > 100 {
+		return errors.New("percent over 100")
+	}
+	p.value, p.valid = value, true
+	return nil
+}
+
+type OptionalProfile struct {
+	Name     Optional[string]
+	Age      Optional[int32]
+	Nickname *Optional[string]
+	Score    Optional[int]
+	Progress Percent
+}
+
+type PlainProfile struct {
+	Name     string
+	Age      *int64
+	Nickname *string
+	Score    Optional[int64]
+	Progress int
+}
+
+func TestCopyNullable(t *testing.T) {
+	t.Run("Should unwrap valid values", func(t *testing.T) {
+		nickname := Some("jz")
+		from := OptionalProfile{Name: Some("jinzhu"), Age: Some[int32](18), Nickname: &nickname, Score: Some(99), Progress: Percent{value: 50, valid: true}}
+
+		to := PlainProfile{}
+		if err := copier.Copy(&to, &from); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if to.Name != "jinzhu" || to.Age == nil || *to.Age != 18 || to.Nickname == nil || *to.Nickname != "jz" || to.Progress != 50 {
+			t.Errorf("Nullable values should be unwrapped, got %#v", to)
+		}
+		if !to.Score.Valid() || to.Score.Get() != 99 {
+			t.Errorf("Nullable values should be converted into other nullable types, got %#v", to.Score)
+		}
+	})
+
+	t.Run("Should leave the destination untouched for invalid values", func(t *testing.T) {
+		age := int64(20)
+		to := PlainProfile{Name: "old", Age: &age}
+		if err := copier.Copy(&to, &OptionalProfile{}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if to.Name != "old" || to.Age != &age || to.Nickname != nil || to.Score.Valid() {
+			t.Errorf("Invalid values should not be copied, got %#v", to)
+		}
+	})
+
+	t.Run("Should wrap plain values", func(t *testing.T) {
+		age, nickname := int64(18), "jz"
+		from := PlainProfile{Name: "jinzhu", Age: &age, Nickname: &nickname, Score: Some[int64](99), Progress: 75}
+
+		to := OptionalProfile{}
+		if err := copier.Copy(&to, &from); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if to.Name.Get() != "jinzhu" || to.Age.Get() != 18 || to.Nickname == nil || to.Nickname.Get() != "jz" || to.Score.Get() != 99 || to.Progress.Get() != 75 {
+			t.Errorf("Plain values should be wrapped, got %#v", to)
+		}
+
+		to = OptionalProfile{}
+		if err := copier.Copy(&to, &PlainProfile{}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if to.Age.Valid() || to.Nickname != nil || !to.Name.Valid() {
+			t.Errorf("Nil pointers should not be wrapped, got %#v", to)
+		}
+	})
+
+	t.Run("Should return the errors of Set", func(t *testing.T) {
+		if err := copier.Copy(&OptionalProfile{}, &PlainProfile{Progress: 150}); err == nil || err.Error() != "percent over 100" {
+			t.Errorf("Should return the error of Set, got %v", err)
+		}
+	})
+
+	t.Run("Should copy top level values", func(t *testing.T) {
+		var s string
+		if err := copier.Copy(&s, Some("x")); err != nil || s != "x" {
+			t.Errorf("Nullable value should be unwrapped, got %q, %v", s, err)
+		}
+
+		var o Optional[string]
+		if err := copier.Copy(&o, "y"); err != nil || !o.Valid() || o.Get() != "y" {
+			t.Errorf("Value should be wrapped, got %#v, %v", o, err)
+		}
+	})
+
+	t.Run("Should copy slices", func(t *testing.T) {
+		strs := []string{}
+		if err := copier.Copy(&strs, []Optional[string]{Some("a"), Some("b")}); err != nil || len(strs) != 2 || strs[0] != "a" || strs[1] != "b" {
+			t.Errorf("Nullable elements should be unwrapped, got %q, %v", strs, err)
+		}
+
+		var optionals []Optional[int64]
+		if err := copier.Copy(&optionals, []int{1, 2}); err != nil || len(optionals) != 2 || optionals[1].Get() != 2 {
+			t.Errorf("Elements should be wrapped, got %#v, %v", optionals, err)
+		}
+
+		mapped, err := copier.MapSlice[Optional[string], string]([]Optional[string]{Some("a"), {}})
+		if err != nil || len(mapped) != 2 || mapped[0] != "a" || mapped[1] != "" {
+			t.Errorf("Nullable elements should be mapped, got %q, %v", mapped, err)
+		}
+	})
+
+	t.Run("Should validate nullable mappings", func(t *testing.T) {
+		if _, err := copier.NewMapper(OptionalProfile{}, PlainProfile{}); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if _, err := copier.NewMapper(PlainProfile{}, OptionalProfile{}); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
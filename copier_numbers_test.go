@@ -0,0 +1,133 @@
+package copier_test
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/jinzhu/copier"
+)
+
+type Measure struct {
+	Count   int64
+	Ratio   float64
+	Enabled bool
+	Code    int
+	Level   *int
+}
+
+type MeasureForm struct {
+	Count   string
+	Ratio   string
+	Enabled string
+	Code    string
+	Level   string
+}
+
+type MeasureRow struct {
+	Count int8
+	Ratio int
+	Code  uint
+}
+
+func TestStrconv(t *testing.T) {
+	level := 3
+	measure := Measure{Count: 1234, Ratio: 0.25, Enabled: true, Code: 65, Level: &level}
+	form := MeasureForm{Count: "1234", Ratio: "0.25", Enabled: "true", Code: "65", Level: "3"}
+	opt := copier.Option{Strconv: true}
+
+	t.Run("Should copy numbers and bools into strings", func(t *testing.T) {
+		var to MeasureForm
+		if err := copier.CopyWithOption(&to, &measure, opt); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(to, form) {
+			t.Errorf("Should format in base 10, got %+v", to)
+		}
+	})
+
+	t.Run("Should copy strings into numbers and bools", func(t *testing.T) {
+		var to Measure
+		if err := copier.CopyWithOption(&to, &form, opt); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(to, measure) {
+			t.Errorf("Should parse the strings, got %+v", to)
+		}
+	})
+
+	t.Run("Should skip empty strings", func(t *testing.T) {
+		to := Measure{Count: 1}
+		if err := copier.CopyWithOption(&to, &MeasureForm{}, opt); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if to.Count != 1 || to.Enabled {
+			t.Errorf("Fields should be untouched, got %+v", to)
+		}
+	})
+
+	t.Run("Should return parse errors with field paths", func(t *testing.T) {
+		var to Measure
+		err := copier.CopyWithOption(&to, &MeasureForm{Ratio: "half"}, opt)
+
+		var pathErr *copier.PathError
+		if !errors.As(err, &pathErr) || pathErr.Path != "Ratio" || !errors.Is(err, strconv.ErrSyntax) {
+			t.Fatalf("Should get a syntax error at Ratio, got %v", err)
+		}
+	})
+
+	t.Run("Should copy slices", func(t *testing.T) {
+		var to []int
+		if err := copier.CopyWithOption(&to, []string{"1", "2"}, opt); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(to, []int{1, 2}) {
+			t.Errorf("Should parse the elements, got %v", to)
+		}
+	})
+}
+
+func TestCheckOverflow(t *testing.T) {
+	opt := copier.Option{CheckOverflow: true}
+
+	t.Run("Should copy numbers which fit", func(t *testing.T) {
+		var to MeasureRow
+		if err := copier.CopyWithOption(&to, &Measure{Count: 127, Ratio: 3, Code: 7}, opt); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if to != (MeasureRow{Count: 127, Ratio: 3, Code: 7}) {
+			t.Errorf("Numbers should be copied, got %+v", to)
+		}
+	})
+
+	for name, from := range map[string]Measure{
+		"Count": {Count: 300},
+		"Ratio": {Ratio: 1.5},
+		"Code":  {Code: -1},
+	} {
+		t.Run("Should fail on overflows of "+name, func(t *testing.T) {
+			var to MeasureRow
+			err := copier.CopyWithOption(&to, &from, opt)
+
+			var pathErr *copier.PathError
+			if !errors.As(err, &pathErr) || pathErr.Path != name || !errors.Is(err, copier.ErrOverflow) {
+				t.Fatalf("Should get ErrOverflow at %v, got %v", name, err)
+			}
+		})
+	}
+
+	t.Run("Should wrap without option", func(t *testing.T) {
+		var to MeasureRow
+		if err := copier.Copy(&to, &Measure{Count: 300}); err != nil || to.Count != 44 {
+			t.Errorf("300 should wrap into 44, got %v, %v", to.Count, err)
+		}
+	})
+
+	t.Run("Should check top level values", func(t *testing.T) {
+		var to uint8
+		if err := copier.CopyWithOption(&to, 256, opt); !errors.Is(err, copier.ErrOverflow) {
+			t.Errorf("Should get ErrOverflow, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package copier_test
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/jinzhu/copier"
+)
+
+func TestPlan(t *testing.T) {
+	plan, err := copier.Plan(&Employee{}, &User{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(plan.Fields) != 8 {
+		t.Errorf("Should fill 8 fields, got %+v", plan.Fields)
+	}
+	if !reflect.DeepEqual(plan.UnmatchedTo, []string{"EmployeID", "SuperRule"}) {
+		t.Errorf("Unexpected unmatched destination fields %v", plan.UnmatchedTo)
+	}
+	if plan.UnmatchedFrom != nil {
+		t.Errorf("Unexpected unmatched source fields %v", plan.UnmatchedFrom)
+	}
+}
+
+func TestPlanUnmatchedFrom(t *testing.T) {
+	employee := EmployeeTags{ID: 100}
+	user := User1{Name: "Dexter Ledesma", DOB: "1 November, 1970", Address: "21 Jump Street", ID: 12345}
+
+	plan, err := copier.Plan(employee, user)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if plan.UnmatchedTo != nil || plan.UnmatchedFrom != nil {
+		t.Errorf("Ignored fields should not be unmatched, got %v, %v", plan.UnmatchedTo, plan.UnmatchedFrom)
+	}
+
+	plan, err = copier.Plan(User2{}, user)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(plan.UnmatchedFrom, []string{"Name"}) {
+		t.Errorf("Unexpected unmatched source fields %v", plan.UnmatchedFrom)
+	}
+
+	if employee.ID != 100 || employee.Name != "" {
+		t.Errorf("Plan should not copy anything")
+	}
+}
+
+func TestPlanConverters(t *testing.T) {
+	type From struct {
+		Age string
+	}
+	type To struct {
+		Age *int
+	}
+
+	plan, err := copier.PlanWithOption(To{}, From{}, copier.Option{
+		Converters: []copier.TypeConverter{
+			{
+				SrcType: "",
+				DstType: 0,
+				Fn: func(src interface{}) (interface{}, error) {
+					return strconv.Atoi(src.(string))
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(plan.Fields) != 1 || !plan.Fields[0].Converter {
+		t.Errorf("Should fill Age with a converter, got %+v", plan.Fields)
+	}
+}
@@ -0,0 +1,71 @@
+package copier_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jinzhu/copier"
+)
+
+type RegisteredFrom struct {
+	Name string
+}
+
+type RegisteredTo struct {
+	Name      string
+	Generated bool
+}
+
+func init() {
+	copier.Register(func(to *RegisteredTo, from *RegisteredFrom) error {
+		if from.Name == "" {
+			return errors.New("empty name")
+		}
+		to.Name = from.Name
+		to.Generated = true
+		return nil
+	})
+}
+
+func TestRegister(t *testing.T) {
+	from := RegisteredFrom{Name: "jinzhu"}
+
+	to := RegisteredTo{}
+	if err := copier.Copy(&to, from); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !to.Generated || to.Name != "jinzhu" {
+		t.Errorf("Copy should dispatch to the registered function, got %#v", to)
+	}
+
+	toPtr := &RegisteredTo{}
+	if err := copier.Copy(&toPtr, &from); err != nil || !toPtr.Generated {
+		t.Errorf("Copy should dispatch to the registered function for pointers, got %#v, %v", toPtr, err)
+	}
+
+	if err := copier.Copy(&RegisteredTo{}, &RegisteredFrom{}); err == nil {
+		t.Errorf("Should return the error of the registered function")
+	}
+
+	reflected := RegisteredTo{}
+	if err := copier.CopyWithOption(&reflected, &from, copier.Option{}); err != nil || reflected.Generated || reflected.Name != "jinzhu" {
+		t.Errorf("CopyWithOption should use reflection, got %#v, %v", reflected, err)
+	}
+}
+
+func TestCopyField(t *testing.T) {
+	var (
+		from = []string{"hello"}
+		to   []*string
+	)
+	if err := copier.CopyField(&to, &from); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(to) != 1 || *to[0] != "hello" {
+		t.Errorf("Field should be copied, got %v", to)
+	}
+
+	if err := copier.CopyField(to, &from); !errors.Is(err, copier.ErrInvalidCopyDestination) {
+		t.Errorf("Should get ErrInvalidCopyDestination, got %v", err)
+	}
+}
@@ -0,0 +1,268 @@
+package copier_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/copier"
+)
+
+// fakeDriver serves the result sets of fakeResults, by query
+type fakeDriver struct{}
+
+type fakeResult struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+var fakeResults = map[string]fakeResult{}
+
+func init() {
+	sql.Register("copier-fake", fakeDriver{})
+}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) {
+	result, ok := fakeResults[query]
+	if !ok {
+		return nil, errors.New("unknown query " + query)
+	}
+	return fakeStmt{result}, nil
+}
+func (fakeConn) Close() error              { return nil }
+func (fakeConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+type fakeStmt struct{ result fakeResult }
+
+func (fakeStmt) Close() error                                    { return nil }
+func (fakeStmt) NumInput() int                                   { return -1 }
+func (fakeStmt) Exec(args []driver.Value) (driver.Result, error) { return nil, errors.New("not supported") }
+func (stmt fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeRows{result: stmt.result}, nil
+}
+
+type fakeRows struct {
+	result fakeResult
+	index  int
+}
+
+func (rows *fakeRows) Columns() []string { return rows.result.columns }
+func (rows *fakeRows) Close() error      { return nil }
+func (rows *fakeRows) Next(dest []driver.Value) error {
+	if rows.index >= len(rows.result.rows) {
+		return io.EOF
+	}
+	copy(dest, rows.result.rows[rows.index])
+	rows.index++
+	return nil
+}
+
+func queryFake(t *testing.T, result fakeResult) *sql.Rows {
+	db, err := sql.Open("copier-fake", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	fakeResults[t.Name()] = result
+	rows, err := db.Query(t.Name())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Cleanup(func() { rows.Close() })
+	return rows
+}
+
+type Audit struct {
+	CreatedAt time.Time
+}
+
+type UserRecord struct {
+	*Audit
+	ID       int
+	UserName string
+	Email    sql.NullString `db:"email_address"`
+	Nickname *string        `db:"nick"`
+	Score    float32
+	Avatar   []byte
+	Internal string `db:"-"`
+}
+
+func TestCopyRows(t *testing.T) {
+	now := time.Now()
+	rows := queryFake(t, fakeResult{
+		columns: []string{"id", "user_name", "email_address", "nick", "score", "avatar", "created_at", "internal", "extra"},
+		rows: [][]driver.Value{
+			{int64(1), "jinzhu", "jinzhu@example.com", "jz", 9.5, []byte("png"), now, "secret", "x"},
+			{int64(2), []byte("copier"), nil, nil, int64(7), nil, now, "secret", "x"},
+		},
+	})
+
+	var users []UserRecord
+	if err := copier.CopyRows(&users, rows); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("Should copy every row, got %#v", users)
+	}
+
+	first, second := users[0], users[1]
+	if first.ID != 1 || first.UserName != "jinzhu" || first.Score != 9.5 || string(first.Avatar) != "png" {
+		t.Errorf("Columns should be copied into fields of the same name, got %#v", first)
+	}
+	if !first.Email.Valid || first.Email.String != "jinzhu@example.com" || first.Nickname == nil || *first.Nickname != "jz" {
+		t.Errorf("Columns should be copied into tagged fields, got %#v", first)
+	}
+	if first.Audit == nil || !first.CreatedAt.Equal(now) || first.Internal != "" {
+		t.Errorf("Columns should be copied into embedded fields only, got %#v", first)
+	}
+	if second.UserName != "copier" || second.Email.Valid || second.Nickname != nil || second.Score != 7 || second.Avatar != nil {
+		t.Errorf("NULL should be copied as zero values, got %#v", second)
+	}
+}
+
+func TestCopyRowsIntoPointers(t *testing.T) {
+	rows := queryFake(t, fakeResult{columns: []string{"ID", "UserName"}, rows: [][]driver.Value{{int64(1), "jinzhu"}}})
+
+	users := []*UserRecord{{ID: 0}}
+	if err := copier.CopyRows(&users, rows); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(users) != 2 || users[1].ID != 1 || users[1].UserName != "jinzhu" {
+		t.Errorf("Rows should be appended, got %#v", users)
+	}
+}
+
+func TestCopyRowsCopierTags(t *testing.T) {
+	type Tagged struct {
+		Nickname string `copier:"nick,must"`
+		Email    string `db:"email_address" copier:"mail"`
+		Manager  string `copier:"Manager.Name"`
+	}
+	rows := queryFake(t, fakeResult{
+		columns: []string{"nick", "mail", "email_address", "Manager.Name", "manager"},
+		rows:    [][]driver.Value{{"jz", "copier@example.com", "jinzhu@example.com", "admin", "root"}},
+	})
+
+	var tagged []Tagged
+	if err := copier.CopyRows(&tagged, rows); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(tagged) != 1 || tagged[0] != (Tagged{Nickname: "jz", Email: "jinzhu@example.com", Manager: "root"}) {
+		t.Errorf("Columns should be copied into fields tagged with their names, db tags first, got %#v", tagged)
+	}
+}
+
+type rowBase struct {
+	Name string
+}
+
+type RowWithPrivateBase struct {
+	*rowBase
+	Age int
+}
+
+func TestCopyRowsUnexportedEmbeddedPointers(t *testing.T) {
+	rows := queryFake(t, fakeResult{columns: []string{"name", "age"}, rows: [][]driver.Value{{"jinzhu", int64(18)}}})
+
+	var records []RowWithPrivateBase
+	if err := copier.CopyRows(&records, rows); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(records) != 1 || records[0].rowBase != nil || records[0].Age != 18 {
+		t.Errorf("Fields of unexported embedded pointers should be skipped, got %#v", records)
+	}
+}
+
+func TestCopyRowsStrict(t *testing.T) {
+	rows := queryFake(t, fakeResult{columns: []string{"id", "extra"}, rows: [][]driver.Value{{int64(1), "x"}}})
+
+	var users []UserRecord
+	err := copier.CopyRowsWithOption(&users, rows, copier.Option{Strict: copier.StrictTo | copier.StrictFrom})
+	if !errors.Is(err, copier.ErrUnmatchedFields) || !strings.Contains(err.Error(), "unmatched columns extra") ||
+		!strings.Contains(err.Error(), "unmatched destination fields CreatedAt, UserName, Email, Nickname, Score, Avatar") {
+		t.Errorf("Should report unmatched columns and fields, got %v", err)
+	}
+}
+
+func TestCopyRowsErrors(t *testing.T) {
+	type Required struct {
+		ID   int
+		Name string `copier:"must,nopanic"`
+	}
+
+	var required []Required
+	if err := copier.CopyRows(&required, queryFake(t, fakeResult{columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}}})); err == nil {
+		t.Errorf("Should fail on must fields without column")
+	}
+
+	var users []UserRecord
+	if err := copier.CopyRows(users, queryFake(t, fakeResult{columns: []string{"id"}})); !errors.Is(err, copier.ErrInvalidCopyDestination) {
+		t.Errorf("Should fail on invalid destination, got %v", err)
+	}
+}
+
+func TestValues(t *testing.T) {
+	now := time.Now()
+	nick := "jz"
+	user := UserRecord{Audit: &Audit{CreatedAt: now}, ID: 1, UserName: "jinzhu", Email: sql.NullString{String: "jinzhu@example.com", Valid: true}, Nickname: &nick, Score: 9.5, Internal: "secret"}
+
+	columns, values, err := copier.Values(&user)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Join(columns, ",") != "created_at,id,user_name,email_address,nick,score,avatar" {
+		t.Errorf("Columns should follow the fields and their tags, got %v", columns)
+	}
+	expected := []driver.Value{now, int64(1), "jinzhu", "jinzhu@example.com", "jz", float64(9.5), []byte(nil)}
+	for i := range expected {
+		if !reflect.DeepEqual(values[i], expected[i]) {
+			t.Errorf("Column %v should be %#v, got %#v", columns[i], expected[i], values[i])
+		}
+	}
+
+	_, values, err = copier.Values(UserRecord{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if values[0] != nil || values[3] != nil || values[4] != nil {
+		t.Errorf("Nil pointers and invalid valuers should be NULL, got %#v", values)
+	}
+
+	columns, _, err = copier.ValuesWithOption(UserRecord{ID: 2}, copier.Option{IgnoreEmpty: true})
+	if err != nil || strings.Join(columns, ",") != "id" {
+		t.Errorf("Zero values and fields of nil embedded pointers should be left out, got %v, %v", columns, err)
+	}
+
+	type Profile struct {
+		Nickname string `copier:"Nick"`
+	}
+	columns, _, err = copier.Values(Profile{})
+	if err != nil || strings.Join(columns, ",") != "nickname" {
+		t.Errorf("Copier tags should not name columns, got %v, %v", columns, err)
+	}
+
+	args, err := copier.NamedArgs(UserRecord{ID: 3})
+	if err != nil || len(args) != 7 || args[1].(sql.NamedArg).Name != "id" || args[1].(sql.NamedArg).Value != int64(3) {
+		t.Errorf("Should return named args, got %#v, %v", args, err)
+	}
+
+	type Invalid struct {
+		Customer Customer
+	}
+	if _, _, err := copier.Values(Invalid{}); !errors.Is(err, copier.ErrNotSupported) {
+		t.Errorf("Should fail on values the driver doesn't support, got %v", err)
+	}
+	if _, _, err := copier.Values(1); !errors.Is(err, copier.ErrInvalidCopyFrom) {
+		t.Errorf("Should fail on values which are not structs, got %v", err)
+	}
+}
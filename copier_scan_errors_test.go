@@ -0,0 +1,125 @@
+package copier_test
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jinzhu/copier"
+)
+
+var errBadEmail = errors.New("bad email")
+
+// Email rejects addresses without an @ when scanned
+type Email struct {
+	Address string
+}
+
+func (e *Email) Scan(src interface{}) error {
+	s, ok := src.(string)
+	if !ok || !strings.Contains(s, "@") {
+		return errBadEmail
+	}
+	e.Address = s
+	return nil
+}
+
+// Secret can't be read back
+type Secret struct {
+	Text string
+}
+
+func (s Secret) Value() (driver.Value, error) {
+	return nil, errors.New("secret")
+}
+
+type Contact struct {
+	Name  string
+	Email string
+}
+
+type ContactRow struct {
+	Name  string
+	Email Email
+}
+
+type Credentials struct {
+	User     string
+	Password Secret
+}
+
+type CredentialsRow struct {
+	User     string
+	Password string
+}
+
+func TestScanErrors(t *testing.T) {
+	t.Run("Should return scanner errors with field paths", func(t *testing.T) {
+		var row ContactRow
+		err := copier.Copy(&row, &Contact{Name: "jinzhu", Email: "jinzhu"})
+
+		var pathErr *copier.PathError
+		if !errors.As(err, &pathErr) || pathErr.Path != "Email" {
+			t.Fatalf("Should get a *PathError at Email, got %v", err)
+		}
+		if !errors.Is(err, errBadEmail) {
+			t.Errorf("Should wrap the Scan error, got %v", err)
+		}
+	})
+
+	t.Run("Should return scanner errors of slice elements", func(t *testing.T) {
+		var rows []ContactRow
+		err := copier.Copy(&rows, []Contact{{Name: "a", Email: "a@example.com"}, {Name: "b", Email: "b"}})
+
+		var pathErr *copier.PathError
+		if !errors.As(err, &pathErr) || pathErr.Path != "[1].Email" {
+			t.Fatalf("Should get a *PathError at [1].Email, got %v", err)
+		}
+	})
+
+	t.Run("Should return valuer errors with field paths", func(t *testing.T) {
+		var row CredentialsRow
+		err := copier.Copy(&row, &Credentials{User: "jinzhu", Password: Secret{Text: "123"}})
+
+		var pathErr *copier.PathError
+		if !errors.As(err, &pathErr) || pathErr.Path != "Password" {
+			t.Fatalf("Should get a *PathError at Password, got %v", err)
+		}
+	})
+
+	t.Run("Should skip errors with OnScanError", func(t *testing.T) {
+		var (
+			skipped []string
+			rows    []ContactRow
+			from    = map[string][]Contact{"team": {{Name: "a", Email: "a@example.com"}, {Name: "b", Email: "b"}}}
+			to      = map[string][]ContactRow{}
+		)
+		opt := copier.Option{OnScanError: func(err *copier.PathError) {
+			if !errors.Is(err, errBadEmail) {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			skipped = append(skipped, err.Path)
+		}}
+
+		if err := copier.CopyWithOption(&rows, from["team"], opt); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := []ContactRow{{Name: "a", Email: Email{Address: "a@example.com"}}, {Name: "b"}}
+		if !reflect.DeepEqual(rows, expected) {
+			t.Errorf("Should skip the failing fields only, got %v", rows)
+		}
+
+		if err := copier.CopyWithOption(&to, from, opt); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(to["team"], expected) {
+			t.Errorf("Should skip the failing fields of map values, got %v", to["team"])
+		}
+
+		if !reflect.DeepEqual(skipped, []string{"[1].Email", "[team][1].Email"}) {
+			t.Errorf("Should report the paths of the skipped fields, got %v", skipped)
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package copier_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/jinzhu/copier"
+)
+
+// userRows iterates over users like sql.Rows
+type userRows struct {
+	users []User
+	index int
+	err   error
+}
+
+func (rows *userRows) Next() bool {
+	if rows.index >= len(rows.users) {
+		return false
+	}
+	rows.index++
+	return true
+}
+
+func (rows *userRows) Scan(dst *User) error {
+	*dst = rows.users[rows.index-1]
+	return nil
+}
+
+func (rows *userRows) Err() error {
+	return rows.err
+}
+
+func newUserRows(n int) *userRows {
+	rows := &userRows{}
+	for i := 0; i < n; i++ {
+		rows.users = append(rows.users, User{Name: "user" + strconv.Itoa(i), Age: int32(i)})
+	}
+	return rows
+}
+
+func TestCopyStream(t *testing.T) {
+	employees, err := copier.CopyStream[User, Employee](context.Background(), newUserRows(5), copier.Option{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(employees) != 5 || employees[4].Name != "user4" || employees[4].Age != 4 || employees[4].DoubleAge != 8 {
+		t.Errorf("Should copy every element, got %#v", employees)
+	}
+
+	pointers, err := copier.CopyStream[User, *Employee](context.Background(), newUserRows(2), copier.Option{})
+	if err != nil || len(pointers) != 2 || pointers[1] == nil || pointers[1].Name != "user1" {
+		t.Errorf("Should allocate pointers, got %v, %v", pointers, err)
+	}
+
+	rows := newUserRows(3)
+	rows.err = errors.New("connection reset")
+	if _, err := copier.CopyStream[User, Employee](context.Background(), rows, copier.Option{}); err != rows.err {
+		t.Errorf("Should return the iterator error, got %v", err)
+	}
+}
+
+func TestCopyBatches(t *testing.T) {
+	var batches [][]Employee
+	err := copier.CopyBatches[User, Employee](context.Background(), newUserRows(7), 3, copier.Option{}, func(batch []Employee) error {
+		batches = append(batches, batch)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(batches) != 3 || len(batches[0]) != 3 || len(batches[2]) != 1 || batches[1][0].Name != "user3" || batches[2][0].Name != "user6" {
+		t.Errorf("Should copy in batches, got %v", batches)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = copier.CopyBatches[User, Employee](context.Background(), newUserRows(7), 2, copier.Option{}, func(batch []Employee) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("Should stop at the first batch error, got %v after %v calls", err, calls)
+	}
+
+	if err := copier.CopyBatches[User, Employee](context.Background(), newUserRows(1), 0, copier.Option{}, func([]Employee) error { return nil }); !errors.Is(err, copier.ErrNotSupported) {
+		t.Errorf("Should reject empty batches, got %v", err)
+	}
+}
+
+func TestCopyChan(t *testing.T) {
+	in, out := make(chan User), make(chan Employee, 10)
+	go func() {
+		for i := 0; i < 3; i++ {
+			in <- User{Name: "user" + strconv.Itoa(i)}
+		}
+		close(in)
+	}()
+
+	if err := copier.CopyChan(context.Background(), out, in, copier.Option{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	close(out)
+
+	var names []string
+	for employee := range out {
+		names = append(names, employee.Name)
+	}
+	if len(names) != 3 || names[2] != "user2" {
+		t.Errorf("Should forward every element in order, got %v", names)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	in, out = make(chan User, 1), make(chan Employee)
+	in <- User{Name: "user0"}
+	go func() {
+		<-out
+		cancel()
+	}()
+
+	err := copier.CopyChan(ctx, out, in, copier.Option{})
+	var pathErr *copier.PathError
+	if !errors.Is(err, context.Canceled) || !errors.As(err, &pathErr) || pathErr.Path != "[1]" {
+		t.Errorf("Should stop when the context is canceled, got %v", err)
+	}
+
+	employees, err := copier.CopyStream[User, Employee](ctx, copier.FromChan(ctx, make(chan User)), copier.Option{})
+	if !errors.Is(err, context.Canceled) || employees != nil {
+		t.Errorf("Should not read with a done context, got %v", err)
+	}
+}
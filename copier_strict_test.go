@@ -0,0 +1,75 @@
+package copier_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jinzhu/copier"
+)
+
+func TestStrictTo(t *testing.T) {
+	user := User{Name: "Jinzhu", Age: 18, Role: "Admin"}
+
+	employee := Employee{}
+	err := copier.CopyWithOption(&employee, &user, copier.Option{Strict: copier.StrictTo})
+	if !errors.Is(err, copier.ErrUnmatchedFields) {
+		t.Fatalf("Should get ErrUnmatchedFields, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "unmatched destination fields EmployeID, SuperRule") {
+		t.Errorf("Should list the unmatched destination fields, got %v", err)
+	}
+	if employee.Name != "" {
+		t.Errorf("Should not copy anything when fields are unmatched")
+	}
+
+	user2 := User2{}
+	if err := copier.CopyWithOption(&user2, &User1{Name: "Jinzhu"}, copier.Option{Strict: copier.StrictTo}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestStrictFrom(t *testing.T) {
+	user := User1{Name: "Dexter Ledesma", DOB: "1 November, 1970", ID: 12345}
+
+	err := copier.CopyWithOption(&User2{}, &user, copier.Option{Strict: copier.StrictFrom})
+	if !errors.Is(err, copier.ErrUnmatchedFields) || !strings.Contains(err.Error(), "unmatched source fields Name") {
+		t.Errorf("Should list the unmatched source fields, got %v", err)
+	}
+
+	employee := EmployeeTags{}
+	if err := copier.CopyWithOption(&employee, &user, copier.Option{Strict: copier.StrictTo | copier.StrictFrom}); err != nil {
+		t.Errorf("Ignored fields should not be reported, got %v", err)
+	}
+	if employee.Name != user.Name {
+		t.Errorf("Name should be copied")
+	}
+}
+
+func TestStrictNested(t *testing.T) {
+	type FromInner struct {
+		A string
+		B string
+	}
+	type ToInner struct {
+		A string
+	}
+	type From struct {
+		Inner FromInner
+	}
+	type To struct {
+		Inner ToInner
+	}
+
+	err := copier.CopyWithOption(&To{}, &From{}, copier.Option{Strict: copier.StrictFrom})
+	if !errors.Is(err, copier.ErrUnmatchedFields) || !strings.Contains(err.Error(), "unmatched source fields B") {
+		t.Errorf("Should check nested structs, got %v", err)
+	}
+}
+
+func TestStrictMapper(t *testing.T) {
+	_, err := copier.NewMapperWithOption(User{}, Employee{}, copier.Option{Strict: copier.StrictTo})
+	if !errors.Is(err, copier.ErrInvalidMapping) || !strings.Contains(err.Error(), "unmatched destination fields EmployeID, SuperRule") {
+		t.Errorf("Mapper should report unmatched fields, got %v", err)
+	}
+}
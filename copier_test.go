@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -1009,6 +1011,163 @@ func TestAnonymousFields(t *testing.T) {
 	})
 }
 
+type HomeContact struct {
+	Phone string
+	Email string
+}
+
+type WorkContact struct {
+	Phone string
+	Email string `copier:"must"`
+}
+
+type Person struct {
+	HomeContact
+	WorkContact
+	Email string
+}
+
+type PersonRow struct {
+	Phone     string
+	Email     string
+	WorkPhone string `copier:"WorkContact.Phone"`
+}
+
+func TestEmbeddedFieldCollisions(t *testing.T) {
+	person := Person{HomeContact: HomeContact{Phone: "home", Email: "home@example.com"}, WorkContact: WorkContact{Phone: "work", Email: "work@example.com"}, Email: "me@example.com"}
+
+	row := PersonRow{}
+	if err := copier.Copy(&row, &person); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if row.Phone != "" {
+		t.Errorf("Ambiguous fields should not be copied, got %v", row.Phone)
+	}
+	if row.Email != "me@example.com" {
+		t.Errorf("Shallower fields should shadow embedded fields, got %v", row.Email)
+	}
+	if row.WorkPhone != "work" {
+		t.Errorf("Path tags should pick an embedded field, got %v", row.WorkPhone)
+	}
+
+	// the must tag of the shadowed WorkContact.Email doesn't apply to Person.Email
+	if err := copier.Copy(&Person{}, &PersonRow{}); err != nil {
+		t.Errorf("Shadowed tags should be ignored, got %v", err)
+	}
+
+	err := copier.CopyWithOption(&PersonRow{}, &person, copier.Option{Strict: copier.StrictFrom})
+	if !errors.Is(err, copier.ErrUnmatchedFields) || !strings.Contains(err.Error(), "ambiguous source fields Phone") {
+		t.Errorf("Strict mode should report ambiguous fields, got %v", err)
+	}
+
+	err = copier.CopyWithOption(&Person{}, &row, copier.Option{Strict: copier.StrictTo})
+	if !errors.Is(err, copier.ErrUnmatchedFields) || !strings.Contains(err.Error(), "ambiguous destination fields Phone") {
+		t.Errorf("Strict mode should report ambiguous fields, got %v", err)
+	}
+
+	type PersonPhones struct {
+		Home string `copier:"HomeContact.Phone"`
+		Work string `copier:"WorkContact.Phone"`
+	}
+	phones := PersonPhones{}
+	if err := copier.CopyWithOption(&phones, &person, copier.Option{Strict: copier.StrictTo}); err != nil {
+		t.Errorf("Ambiguous fields picked with tags should not be reported, got %v", err)
+	}
+	if phones.Home != "home" || phones.Work != "work" {
+		t.Errorf("Path tags should pick embedded fields, got %#v", phones)
+	}
+}
+
+type AuditTimes struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time `copier:"must,nopanic"`
+}
+
+type Article struct {
+	*AuditTimes
+	Title string
+}
+
+type ArticleRow struct {
+	AuditTimes
+	Title string
+}
+
+func TestEmbeddedStructs(t *testing.T) {
+	now := time.Now()
+
+	article := Article{}
+	if err := copier.Copy(&article, &Article{Title: "copier"}); err == nil {
+		t.Errorf("Must fields of nil embedded structs should not be copied")
+	}
+	if article.AuditTimes != nil || article.Title != "copier" {
+		t.Errorf("Nil embedded pointers should leave the dest pointer nil, got %#v", article)
+	}
+
+	from := Article{AuditTimes: &AuditTimes{CreatedAt: now, UpdatedAt: now}, Title: "copier"}
+	if err := copier.Copy(&article, &from); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if article.AuditTimes == nil || article.AuditTimes == from.AuditTimes || !article.UpdatedAt.Equal(now) {
+		t.Errorf("Embedded pointers should be copied, not shared, got %#v", article)
+	}
+
+	row := ArticleRow{}
+	if err := copier.CopyWithOption(&row, &from, copier.Option{Strict: copier.StrictTo | copier.StrictFrom}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !row.CreatedAt.Equal(now) || row.Title != "copier" {
+		t.Errorf("Embedded structs should be copied as a whole, got %#v", row)
+	}
+
+	plan, err := copier.Plan(&row, &from)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(plan.Fields) != 2 || plan.Fields[0].To != "AuditTimes" || plan.Fields[0].From != "AuditTimes" {
+		t.Errorf("Embedded structs should be mapped as a whole, got %+v", plan.Fields)
+	}
+
+	article = Article{}
+	if err := copier.Copy(&article, &ArticleRow{Title: "row"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if article.AuditTimes == nil || !article.CreatedAt.IsZero() {
+		t.Errorf("Embedded values should be copied into embedded pointers, got %#v", article)
+	}
+}
+
+type revision struct {
+	Revision int
+}
+
+type Revisioned struct {
+	revision
+	Title string
+}
+
+func TestUnexportedEmbeddedStructs(t *testing.T) {
+	from := Revisioned{revision: revision{Revision: 3}, Title: "copier"}
+
+	for i := 0; i < 2; i++ {
+		clone, err := copier.Clone(from)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if clone != from {
+			t.Errorf("Unexported embedded structs should be cloned, got %#v", clone)
+		}
+
+		copied := Revisioned{}
+		if err := copier.Copy(&copied, &from); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if copied.Revision != 3 || copied.Title != "copier" {
+			t.Errorf("Promoted fields of unexported embedded structs should be copied, got %#v", copied)
+		}
+	}
+}
+
 type someStruct struct {
 	IntField  int
 	UIntField uint64
@@ -1091,6 +1250,180 @@ func TestCopyMapOfInt(t *testing.T) {
 	}
 }
 
+func TestCopyMapWithStructKeys(t *testing.T) {
+	type Key1 struct {
+		ID   int
+		Name string
+	}
+	type Key2 struct {
+		ID   int64
+		Name string
+	}
+
+	t.Run("Should copy struct keys", func(t *testing.T) {
+		from := map[Key1]Foo1{{ID: 1, Name: "a"}: {Name: "foo", Age: 10}}
+		to := map[Key2]Foo2{}
+		if err := copier.Copy(&to, from); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if v, ok := to[Key2{ID: 1, Name: "a"}]; !ok || v.Name != "foo" {
+			t.Errorf("Map with struct keys should be copied, got %v", to)
+		}
+	})
+
+	t.Run("Should copy pointer to struct keys", func(t *testing.T) {
+		key := &Key1{ID: 2, Name: "b"}
+		from := map[*Key1]*Foo1{key: {Name: "foo"}}
+		to := map[*Key2]*Foo2{}
+		if err := copier.Copy(&to, from); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(to) != 1 {
+			t.Fatalf("Map should have one elem, got %v", len(to))
+		}
+		for k, v := range to {
+			if k.ID != 2 || k.Name != "b" || v.Name != "foo" {
+				t.Errorf("Map with pointer keys should be copied, got %v: %v", k, v)
+			}
+		}
+	})
+
+	t.Run("Should return error with incompatible keys", func(t *testing.T) {
+		from := map[string]int{"a": 1}
+		to := map[Key1]int{}
+		if err := copier.Copy(&to, from); !errors.Is(err, copier.ErrMapKeyNotMatch) {
+			t.Errorf("Should get ErrMapKeyNotMatch, got %v", err)
+		}
+	})
+}
+
+func TestCopyMapWithConverters(t *testing.T) {
+	from := map[string]string{"1": "one", "2": "two"}
+	to := map[int]string{}
+	err := copier.CopyWithOption(&to, from, copier.Option{
+		Converters: []copier.TypeConverter{
+			{
+				SrcType: "",
+				DstType: 0,
+				Fn: func(src interface{}) (interface{}, error) {
+					return strconv.Atoi(src.(string))
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if to[1] != "one" || to[2] != "two" {
+		t.Errorf("Map keys should be converted, got %v", to)
+	}
+
+	bad := map[string]string{"x": "ex"}
+	err = copier.CopyWithOption(&to, bad, copier.Option{
+		Converters: []copier.TypeConverter{
+			{
+				SrcType: "",
+				DstType: 0,
+				Fn: func(src interface{}) (interface{}, error) {
+					return strconv.Atoi(src.(string))
+				},
+			},
+		},
+	})
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Should raise the error of the converter, got %v", err)
+	}
+}
+
+func TestDeepCopyNestedMap(t *testing.T) {
+	from := map[string]map[string][]int{"a": {"b": {1, 2}}}
+	to := map[string]map[string][]int{}
+	if err := copier.CopyWithOption(&to, from, copier.Option{DeepCopy: true}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	from["a"]["b"][0] = 10
+	from["a"]["c"] = []int{3}
+
+	if to["a"]["b"][0] != 1 {
+		t.Errorf("Nested slice should be deep copied")
+	}
+	if _, ok := to["a"]["c"]; ok {
+		t.Errorf("Nested map should be deep copied")
+	}
+}
+
+type Attribute struct {
+	Key   string `copier:"key"`
+	Value string `copier:"value"`
+}
+
+type AttributeID struct {
+	Name string `copier:"key"`
+	ID   int64  `copier:"value"`
+}
+
+func TestCopyMapToPairSlice(t *testing.T) {
+	t.Run("Should copy map to slice of pairs", func(t *testing.T) {
+		from := map[string]string{"b": "2", "a": "1"}
+		var to []Attribute
+		if err := copier.Copy(&to, from); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(to) != 2 || to[0] != (Attribute{Key: "a", Value: "1"}) || to[1] != (Attribute{Key: "b", Value: "2"}) {
+			t.Errorf("Map should be copied to sorted pairs, got %v", to)
+		}
+	})
+
+	t.Run("Should copy map to slice of pair pointers", func(t *testing.T) {
+		from := map[string]int{"a": 1}
+		var to []*AttributeID
+		if err := copier.Copy(&to, from); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(to) != 1 || to[0].Name != "a" || to[0].ID != 1 {
+			t.Errorf("Map should be copied to pairs, got %v", to)
+		}
+	})
+
+	t.Run("Should copy slice of pairs to map", func(t *testing.T) {
+		from := []*Attribute{{Key: "a", Value: "1"}, nil, {Key: "b", Value: "2"}}
+		var to map[string]string
+		if err := copier.Copy(&to, from); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(to) != 2 || to["a"] != "1" || to["b"] != "2" {
+			t.Errorf("Pairs should be copied to map, got %v", to)
+		}
+	})
+
+	t.Run("Should copy pairs in struct fields", func(t *testing.T) {
+		type Domain struct {
+			Attributes map[string]string
+		}
+		type Row struct {
+			Attributes []Attribute
+		}
+
+		from := Domain{Attributes: map[string]string{"a": "1"}}
+		row := Row{}
+		if err := copier.Copy(&row, from); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(row.Attributes) != 1 || row.Attributes[0] != (Attribute{Key: "a", Value: "1"}) {
+			t.Errorf("Map field should be copied to pairs, got %v", row.Attributes)
+		}
+
+		domain := Domain{}
+		if err := copier.Copy(&domain, row); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if domain.Attributes["a"] != "1" {
+			t.Errorf("Pairs field should be copied to map, got %v", domain.Attributes)
+		}
+	})
+}
+
 func TestCopyWithOption(t *testing.T) {
 	from := structSameName2{D: "456", E: &someStruct{IntField: 100, UIntField: 1000}}
 	to := &structSameName1{A: "123", B: 2, C: time.Now(), D: "123", E: &someStruct{UIntField: 5000}}
@@ -1105,6 +1438,77 @@ func TestCopyWithOption(t *testing.T) {
 	}
 }
 
+type Service struct {
+	Name    string
+	Events  chan string
+	Handler func() string
+}
+
+func TestUncopyablePolicy(t *testing.T) {
+	from := Service{Name: "svc", Events: make(chan string), Handler: func() string { return "hello" }}
+
+	t.Run("Should share by default", func(t *testing.T) {
+		to := Service{}
+		if err := copier.CopyWithOption(&to, &from, copier.Option{DeepCopy: true}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if to.Events != from.Events || to.Handler == nil || to.Handler() != "hello" {
+			t.Errorf("Channel and func should be shared")
+		}
+	})
+
+	t.Run("Should skip", func(t *testing.T) {
+		events := make(chan string)
+		to := Service{Events: events}
+		if err := copier.CopyWithOption(&to, &from, copier.Option{DeepCopy: true, Uncopyable: copier.UncopyableSkip}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if to.Events != events || to.Handler != nil || to.Name != "svc" {
+			t.Errorf("Channel and func should be skipped")
+		}
+	})
+
+	t.Run("Should zero", func(t *testing.T) {
+		to := Service{Events: make(chan string), Handler: func() string { return "" }}
+		if err := copier.CopyWithOption(&to, &from, copier.Option{DeepCopy: true, Uncopyable: copier.UncopyableZero}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if to.Events != nil || to.Handler != nil || to.Name != "svc" {
+			t.Errorf("Channel and func should be zeroed")
+		}
+	})
+
+	t.Run("Should return error", func(t *testing.T) {
+		to := Service{}
+		err := copier.CopyWithOption(&to, &from, copier.Option{DeepCopy: true, Uncopyable: copier.UncopyableError})
+		if !errors.Is(err, copier.ErrUncopyable) {
+			t.Errorf("Should get ErrUncopyable, got %v", err)
+		}
+
+		if err := copier.CopyWithOption(&to, &Service{Name: "nil"}, copier.Option{Uncopyable: copier.UncopyableError}); err != nil {
+			t.Errorf("Nil channel and func should be copied, got %v", err)
+		}
+	})
+
+	t.Run("Should apply to top level values", func(t *testing.T) {
+		events := make(chan string)
+		if err := copier.CopyWithOption(&events, from.Events, copier.Option{Uncopyable: copier.UncopyableSkip}); err != nil || events == from.Events {
+			t.Errorf("Channel should be skipped, got %v", err)
+		}
+		if err := copier.CopyWithOption(&events, from.Events, copier.Option{Uncopyable: copier.UncopyableZero}); err != nil || events != nil {
+			t.Errorf("Channel should be zeroed, got %v", err)
+		}
+
+		var handler func() string
+		if err := copier.CopyWithOption(&handler, from.Handler, copier.Option{Uncopyable: copier.UncopyableError}); !errors.Is(err, copier.ErrUncopyable) || handler != nil {
+			t.Errorf("Should get ErrUncopyable, got %v", err)
+		}
+		if err := copier.Copy(&handler, from.Handler); err != nil || handler == nil || handler() != "hello" {
+			t.Errorf("Func should be shared by default, got %v", err)
+		}
+	})
+}
+
 type ScannerValue struct {
 	V int
 }
@@ -1240,3 +1644,90 @@ func TestDeepCopyInterface(t *testing.T) {
 		t.Errorf("to value failed to be deep copied")
 	}
 }
+
+func TestCopyUnexportedFields(t *testing.T) {
+	option := copier.Option{DeepCopy: true, CopyUnexported: true}
+
+	t.Run("Should clone unexported fields", func(t *testing.T) {
+		user := User{Name: "Jinzhu", Notes: []string{"hello"}, flags: []byte{'x'}}
+		clone := User{}
+		if err := copier.CopyWithOption(&clone, &user, option); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if clone.Name != user.Name || string(clone.flags) != "x" {
+			t.Errorf("Unexported fields should be copied, got %#v", clone)
+		}
+
+		user.flags[0] = 'y'
+		if clone.flags[0] != 'x' {
+			t.Errorf("Unexported fields should be deep copied")
+		}
+	})
+
+	t.Run("Should clone unexported fields from value", func(t *testing.T) {
+		user := User{flags: []byte{'x'}}
+		clone := User{}
+		if err := copier.CopyWithOption(&clone, user, option); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if string(clone.flags) != "x" {
+			t.Errorf("Unexported fields should be copied, got %#v", clone)
+		}
+	})
+
+	t.Run("Should clone nested unexported fields", func(t *testing.T) {
+		type state struct {
+			count int
+		}
+		type Counter struct {
+			*state
+			name  string
+			items map[string]*state
+		}
+
+		from := Counter{state: &state{count: 1}, name: "counter", items: map[string]*state{"a": {count: 2}}}
+		to := Counter{}
+		if err := copier.CopyWithOption(&to, &from, option); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if to.state == nil || to.state == from.state || to.count != 1 || to.name != "counter" {
+			t.Errorf("Embedded unexported fields should be cloned, got %#v", to)
+		}
+		if to.items["a"] == from.items["a"] || to.items["a"].count != 2 {
+			t.Errorf("Unexported map should be cloned, got %#v", to.items)
+		}
+	})
+
+	t.Run("Should copy values of other packages as a whole", func(t *testing.T) {
+		type Event struct {
+			At    time.Time
+			Local time.Time
+			Times []time.Time
+		}
+
+		jst := time.FixedZone("JST", 9*60*60)
+		from := Event{At: time.Date(2021, 1, 2, 3, 4, 5, 0, jst), Local: time.Now(), Times: []time.Time{time.Date(2021, 1, 2, 3, 4, 5, 0, jst)}}
+		to := Event{}
+		if err := copier.CopyWithOption(&to, &from, option); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if to.At.String() != from.At.String() || to.At.Location() != jst || to.Local.Location() != time.Local || to.Times[0].Location() != jst {
+			t.Errorf("Times should keep their locations, got %v, %v, %v", to.At, to.Local, to.Times)
+		}
+	})
+
+	t.Run("Should not copy unexported fields without option", func(t *testing.T) {
+		user := User{flags: []byte{'x'}}
+		clone := User{}
+		copier.CopyWithOption(&clone, &user, copier.Option{DeepCopy: true})
+		if clone.flags != nil {
+			t.Errorf("Unexported fields should not be copied")
+		}
+
+		employee := Employee{}
+		copier.CopyWithOption(&employee, &user, option)
+		if employee.flags != nil {
+			t.Errorf("Unexported fields should not be copied between different types")
+		}
+	})
+}
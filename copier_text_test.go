@@ -0,0 +1,139 @@
+package copier_test
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/copier"
+)
+
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelError
+)
+
+var levelNames = []string{"debug", "info", "error"}
+
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+func (l *Level) UnmarshalText(text []byte) error {
+	for i, name := range levelNames {
+		if name == string(text) {
+			*l = Level(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown level %q", text)
+}
+
+type LogConfig struct {
+	Level     Level
+	Server    net.IP
+	StartedAt *time.Time
+	Limits    map[Level]int
+}
+
+type LogConfigJSON struct {
+	Level     string
+	Server    string
+	StartedAt string
+	Limits    map[string]int
+}
+
+func TestTextMarshaling(t *testing.T) {
+	startedAt := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	config := LogConfig{
+		Level:     LevelError,
+		Server:    net.ParseIP("10.0.0.1"),
+		StartedAt: &startedAt,
+		Limits:    map[Level]int{LevelDebug: 10, LevelInfo: 100},
+	}
+	expected := LogConfigJSON{
+		Level:     "error",
+		Server:    "10.0.0.1",
+		StartedAt: "2021-03-04T05:06:07Z",
+		Limits:    map[string]int{"debug": 10, "info": 100},
+	}
+	opt := copier.Option{TextMarshaling: true}
+
+	t.Run("Should copy values into text", func(t *testing.T) {
+		var to LogConfigJSON
+		if err := copier.CopyWithOption(&to, &config, opt); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(to, expected) {
+			t.Errorf("Should marshal the values, got %+v", to)
+		}
+	})
+
+	t.Run("Should copy text into values", func(t *testing.T) {
+		var to LogConfig
+		if err := copier.CopyWithOption(&to, &expected, opt); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(to, config) {
+			t.Errorf("Should unmarshal the text, got %+v", to)
+		}
+	})
+
+	t.Run("Should copy slices and top level values", func(t *testing.T) {
+		var names []string
+		if err := copier.CopyWithOption(&names, []Level{LevelInfo, LevelDebug}, opt); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(names, []string{"info", "debug"}) {
+			t.Errorf("Should copy Stringer elements, got %v", names)
+		}
+
+		var times []string
+		if err := copier.CopyWithOption(&times, []time.Time{startedAt}, opt); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(times, []string{"2021-03-04T05:06:07Z"}) {
+			t.Errorf("Should copy TextMarshaler elements, got %v", times)
+		}
+
+		var level Level
+		if err := copier.CopyWithOption(&level, "error", opt); err != nil || level != LevelError {
+			t.Errorf("Should unmarshal top level values, got %v, %v", level, err)
+		}
+	})
+
+	t.Run("Should return unmarshal errors with field paths", func(t *testing.T) {
+		var to LogConfig
+		err := copier.CopyWithOption(&to, &LogConfigJSON{Level: "fatal"}, opt)
+
+		var pathErr *copier.PathError
+		if !errors.As(err, &pathErr) || pathErr.Path != "Level" {
+			t.Fatalf("Should get a *PathError at Level, got %v", err)
+		}
+	})
+
+	t.Run("Should not copy text without option", func(t *testing.T) {
+		var to LogConfigJSON
+		if err := copier.Copy(&to, &LogConfig{StartedAt: &startedAt}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if to.StartedAt != "" {
+			t.Errorf("StartedAt should not be copied, got %q", to.StartedAt)
+		}
+	})
+
+	t.Run("Should validate mappers", func(t *testing.T) {
+		if _, err := copier.NewMapper(LogConfig{}, LogConfigJSON{}); err == nil {
+			t.Errorf("Fields should not be copyable without option")
+		}
+		if _, err := copier.NewMapperWithOption(LogConfig{}, LogConfigJSON{}, opt); err != nil {
+			t.Errorf("Fields should be copyable, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,119 @@
+package copier_test
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/copier"
+)
+
+type Session struct {
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	ExpiresAt sql.NullTime
+	ClosedAt  sql.NullTime
+	Timeout   time.Duration
+}
+
+type SessionJSON struct {
+	CreatedAt string
+	UpdatedAt int64
+	ExpiresAt *string
+	ClosedAt  string
+	Timeout   string
+}
+
+func TestTimes(t *testing.T) {
+	var (
+		createdAt = time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+		updatedAt = createdAt.Add(time.Hour)
+		expiresAt = createdAt.Add(24 * time.Hour)
+		session   = Session{
+			CreatedAt: createdAt,
+			UpdatedAt: &updatedAt,
+			ExpiresAt: sql.NullTime{Time: expiresAt, Valid: true},
+			Timeout:   90 * time.Second,
+		}
+		expiresJSON = "2021-03-05T05:06:07Z"
+		sessionJSON = SessionJSON{
+			CreatedAt: "2021-03-04T05:06:07Z",
+			UpdatedAt: updatedAt.Unix(),
+			ExpiresAt: &expiresJSON,
+			Timeout:   "1m30s",
+		}
+		opt = copier.Option{Times: true}
+	)
+
+	t.Run("Should copy times into strings and Unix seconds", func(t *testing.T) {
+		var to SessionJSON
+		if err := copier.CopyWithOption(&to, &session, opt); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(to, sessionJSON) {
+			t.Errorf("Should format the times, got %+v", to)
+		}
+	})
+
+	t.Run("Should copy strings and Unix seconds into times", func(t *testing.T) {
+		var to Session
+		if err := copier.CopyWithOption(&to, &sessionJSON, opt); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !to.CreatedAt.Equal(createdAt) || to.UpdatedAt == nil || !to.UpdatedAt.Equal(updatedAt) {
+			t.Errorf("Should parse the times, got %v, %v", to.CreatedAt, to.UpdatedAt)
+		}
+		if !to.ExpiresAt.Valid || !to.ExpiresAt.Time.Equal(expiresAt) {
+			t.Errorf("Should copy into sql.NullTime, got %v", to.ExpiresAt)
+		}
+		if to.ClosedAt.Valid {
+			t.Errorf("Empty strings should leave sql.NullTime invalid, got %v", to.ClosedAt)
+		}
+		if to.Timeout != 90*time.Second {
+			t.Errorf("Should parse durations, got %v", to.Timeout)
+		}
+	})
+
+	t.Run("Should use the layout and location", func(t *testing.T) {
+		tokyo := time.FixedZone("Tokyo", 9*60*60)
+		opt := copier.Option{Times: true, TimeLayout: "2006-01-02 15:04", TimeLocation: tokyo}
+
+		var to SessionJSON
+		if err := copier.CopyWithOption(&to, &session, opt); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if to.CreatedAt != "2021-03-04 14:06" {
+			t.Errorf("Should format with the layout in the location, got %q", to.CreatedAt)
+		}
+
+		var from Session
+		if err := copier.CopyWithOption(&from, &SessionJSON{CreatedAt: "2021-03-04 14:06", UpdatedAt: createdAt.Unix()}, opt); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !from.CreatedAt.Equal(createdAt.Truncate(time.Minute)) || from.CreatedAt.Location() != tokyo || from.UpdatedAt.Location() != tokyo {
+			t.Errorf("Should parse in the location, got %v, %v", from.CreatedAt, from.UpdatedAt)
+		}
+	})
+
+	t.Run("Should return parse errors with field paths", func(t *testing.T) {
+		var to Session
+		err := copier.CopyWithOption(&to, &SessionJSON{CreatedAt: "yesterday"}, opt)
+
+		var pathErr *copier.PathError
+		if !errors.As(err, &pathErr) || pathErr.Path != "CreatedAt" {
+			t.Fatalf("Should get a *PathError at CreatedAt, got %v", err)
+		}
+	})
+
+	t.Run("Should copy slices", func(t *testing.T) {
+		var to []string
+		if err := copier.CopyWithOption(&to, []*time.Time{&createdAt, nil}, opt); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(to, []string{"2021-03-04T05:06:07Z", ""}) {
+			t.Errorf("Should format the elements, nil pointers as empty strings, got %v", to)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package copier_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/jinzhu/copier"
+)
+
+func TestCopyWithWorkers(t *testing.T) {
+	users := make([]User, 1000)
+	for i := range users {
+		users[i] = User{Name: "user" + strconv.Itoa(i), Age: int32(i), Notes: []string{strconv.Itoa(i)}}
+	}
+
+	var sequential, concurrent []Employee
+	if err := copier.Copy(&sequential, &users); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := copier.CopyWithOption(&concurrent, &users, copier.Option{Workers: 8}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(sequential, concurrent) {
+		t.Errorf("Workers should copy the same elements in the same order")
+	}
+
+	pointers := []*Employee{{Name: "existing"}}
+	if err := copier.CopyWithOption(&pointers, &users, copier.Option{Workers: 8}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(pointers) != len(users) || pointers[999] == nil || pointers[999].Name != "user999" || pointers[0].Name != "user0" {
+		t.Errorf("Workers should fill the pre-sized slice")
+	}
+
+	matrix := [][]int{{1}, {2, 3}, {4, 5, 6}}
+	var copied [][]int
+	if err := copier.CopyWithOption(&copied, &matrix, copier.Option{DeepCopy: true, Workers: 2}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(copied, matrix) || &copied[2][0] == &matrix[2][0] {
+		t.Errorf("Workers should deep copy nested slices, got %v", copied)
+	}
+}
+
+func TestCopyWithWorkersErrors(t *testing.T) {
+	items := make([]OrderItem, 100)
+	for i := range items {
+		items[i] = OrderItem{Price: i}
+	}
+
+	converter := copier.TypeConverter{
+		SrcType: 0,
+		DstType: "",
+		Fn: func(src interface{}) (interface{}, error) {
+			if price := src.(int); price%30 == 29 {
+				return nil, fmt.Errorf("price %d", price)
+			}
+			return strconv.Itoa(src.(int)), nil
+		},
+	}
+
+	var rows []OrderItemRow
+	err := copier.CopyWithOption(&rows, &items, copier.Option{Converters: []copier.TypeConverter{converter}, Workers: 4})
+	if err == nil || err.Error() != "price 29" {
+		t.Errorf("Should return the error of the first failing element, got %v", err)
+	}
+
+	var sequentialRows []OrderItemRow
+	copier.CopyWithOption(&sequentialRows, &items, copier.Option{Converters: []copier.TypeConverter{converter}})
+	if len(rows) != 29 || len(sequentialRows) != 29 {
+		t.Errorf("Should stop before the failing element, got %d and %d elements", len(rows), len(sequentialRows))
+	}
+
+	numbers := make([]int, 100)
+	numbers[29] = 300
+	sequentialBytes, concurrentBytes := []int8{}, []int8{}
+	copier.CopyWithOption(&sequentialBytes, numbers, copier.Option{CheckOverflow: true})
+	err = copier.CopyWithOption(&concurrentBytes, numbers, copier.Option{CheckOverflow: true, Workers: 4})
+	if !errors.Is(err, copier.ErrOverflow) || len(concurrentBytes) != len(sequentialBytes) || len(concurrentBytes) != 30 {
+		t.Errorf("Should end with the failing element like sequential copies, got %d and %d elements, %v", len(concurrentBytes), len(sequentialBytes), err)
+	}
+
+	type Title struct {
+		Name string `copier:"must"`
+	}
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("Should raise the panics of the workers")
+		}
+	}()
+	var titles []Title
+	copier.CopyWithOption(&titles, make([]struct{}, 10), copier.Option{Workers: 4})
+	t.Errorf("Should not return")
+}
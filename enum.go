@@ -0,0 +1,71 @@
+package copier
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// enums holds the registered enum tables, map[interface{}]interface{} by typePair
+var enums sync.Map
+
+// RegisterEnum registers a table of enum values, values of type A are copied into values of
+// type B with the table, and values of type B into values of type A with the inverse table.
+// Values missing from the table fail with ErrUnknownEnum. It panics when two values of type
+// A map to the same value of type B, as the table couldn't be inverted
+func RegisterEnum[A, B comparable](table map[A]B) {
+	var (
+		forward = make(map[interface{}]interface{}, len(table))
+		inverse = make(map[interface{}]interface{}, len(table))
+		a       = reflect.TypeOf((*A)(nil)).Elem()
+		b       = reflect.TypeOf((*B)(nil)).Elem()
+	)
+	for from, to := range table {
+		if other, ok := inverse[to]; ok {
+			panic(fmt.Sprintf("copier: enum values %v and %v of %v both map to %v", other, from, a, to))
+		}
+		forward[from], inverse[to] = to, from
+	}
+
+	enums.Store(typePair{to: b, from: a}, forward)
+	enums.Store(typePair{to: a, from: b}, inverse)
+}
+
+// enumOf returns the enum table registered to copy values of type from into values of type
+// to, or nil
+func enumOf(to, from reflect.Type) map[interface{}]interface{} {
+	table, ok := enums.Load(typePair{to: indirectElem(to), from: indirectElem(from)})
+	if !ok {
+		return nil
+	}
+	return table.(map[interface{}]interface{})
+}
+
+// setEnum copies from into to with their registered enum table
+func setEnum(to, from reflect.Value) (bool, error) {
+	table := enumOf(to.Type(), from.Type())
+	if table == nil {
+		return false, nil
+	}
+
+	if from = indirect(from); !from.IsValid() {
+		if to.Kind() == reflect.Ptr {
+			to.Set(reflect.Zero(to.Type()))
+		}
+		return true, nil
+	}
+
+	value, ok := table[from.Interface()]
+	if !ok {
+		return false, &PathError{Err: fmt.Errorf("%w: %v %v", ErrUnknownEnum, from.Type(), from)}
+	}
+
+	for to.Kind() == reflect.Ptr {
+		if to.IsNil() {
+			to.Set(reflect.New(to.Type().Elem()))
+		}
+		to = to.Elem()
+	}
+	to.Set(reflect.ValueOf(value))
+	return true, nil
+}
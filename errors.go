@@ -1,10 +1,94 @@
 package copier
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
 
 var (
 	ErrInvalidCopyDestination = errors.New("copy destination is invalid")
 	ErrInvalidCopyFrom        = errors.New("copy from is invalid")
 	ErrMapKeyNotMatch         = errors.New("map's key type doesn't match")
 	ErrNotSupported           = errors.New("not supported")
+	ErrInvalidMapping         = errors.New("invalid mapping")
+	ErrUnmatchedFields        = errors.New("unmatched fields")
+	ErrUncopyable             = errors.New("channels and functions can't be copied")
+	ErrOverflow               = errors.New("number doesn't fit in the destination type")
+	ErrUnknownEnum            = errors.New("unknown enum value")
 )
+
+// PathError records where a copy stopped, such as Orders[3].Items[0].Price, when it is
+// interrupted by its context or a Scan or Value method fails
+type PathError struct {
+	Path string
+	Err  error
+}
+
+func (e *PathError) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// withPath prepends a field name, an index or a key to the path of err, if it is a *PathError
+func withPath(err error, segment string) error {
+	var pathErr *PathError
+	if !errors.As(err, &pathErr) {
+		return err
+	}
+
+	switch {
+	case pathErr.Path == "":
+		pathErr.Path = segment
+	case strings.HasPrefix(pathErr.Path, "["):
+		pathErr.Path = segment + pathErr.Path
+	default:
+		pathErr.Path = segment + "." + pathErr.Path
+	}
+	return err
+}
+
+func indexPath(i int) string {
+	return fmt.Sprintf("[%d]", i)
+}
+
+func keyPath(key reflect.Value) string {
+	return fmt.Sprintf("[%v]", key)
+}
+
+// pathNode is the path of the value being copied, it is only tracked for OnScanError
+type pathNode struct {
+	parent *pathNode
+	field  string
+	key    reflect.Value
+	index  int
+}
+
+func (p *pathNode) String() string {
+	if p == nil {
+		return ""
+	}
+
+	var segment string
+	switch {
+	case p.field != "":
+		segment = p.field
+	case p.key.IsValid():
+		segment = keyPath(p.key)
+	default:
+		segment = indexPath(p.index)
+	}
+
+	parent := p.parent.String()
+	if parent == "" || strings.HasPrefix(segment, "[") {
+		return parent + segment
+	}
+	return parent + "." + segment
+}
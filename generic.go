@@ -0,0 +1,47 @@
+package copier
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Clone returns a deep copy of src, including its unexported fields
+func Clone[T any](src T) (T, error) {
+	return copyTo[T](src, Option{DeepCopy: true, CopyUnexported: true})
+}
+
+// Map copies src into a new value of type D
+func Map[S, D any](src S) (D, error) {
+	return copyTo[D](src, Option{})
+}
+
+// MapSlice copies every element of src into a new slice of D
+func MapSlice[S, D any](src []S) ([]D, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	dst := make([]D, len(src))
+	for i := range src {
+		var err error
+		if dst[i], err = Map[S, D](src[i]); err != nil {
+			return nil, fmt.Errorf("index %d: %w", i, err)
+		}
+	}
+	return dst, nil
+}
+
+// copyTo copies src into a new value of type D, allocating D if it is a pointer
+func copyTo[D any](src interface{}, opt Option) (D, error) {
+	dst := new(D)
+	if from := reflect.ValueOf(src); !from.IsValid() || (from.Kind() == reflect.Ptr && from.IsNil()) {
+		return *dst, nil
+	}
+
+	for to := reflect.ValueOf(dst).Elem(); to.Kind() == reflect.Ptr; to = to.Elem() {
+		to.Set(reflect.New(to.Type().Elem()))
+	}
+
+	err := copier(dst, src, opt)
+	return *dst, err
+}
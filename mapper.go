@@ -0,0 +1,259 @@
+package copier
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// MappingKind defines how a destination field is filled
+type MappingKind uint8
+
+const (
+	// MappingField copies a source field into a destination field
+	MappingField MappingKind = iota
+	// MappingSetter calls a destination method with a source field
+	MappingSetter
+	// MappingGetter copies the result of a source method into a destination field
+	MappingGetter
+)
+
+func (kind MappingKind) String() string {
+	switch kind {
+	case MappingSetter:
+		return "setter"
+	case MappingGetter:
+		return "getter"
+	}
+	return "field"
+}
+
+// FieldMapping describes a destination field, or setter method, and the source it is copied from
+type FieldMapping struct {
+	To   string
+	From string
+	Kind MappingKind
+	// Converter is true when the value is converted with one of Option.Converters
+	Converter bool
+}
+
+// Mapper copies values of a struct type into another struct type, the mapping between both
+// types is computed and validated once when the mapper is created
+type Mapper struct {
+	mapping *structMapping
+	opt     Option
+}
+
+// NewMapper returns a mapper copying values of the type of from into values of the type of to
+func NewMapper(from, to interface{}) (*Mapper, error) {
+	return NewMapperWithOption(from, to, Option{})
+}
+
+// NewMapperWithOption returns a mapper copying values of the type of from into values of the
+// type of to, with the given options
+func NewMapperWithOption(from, to interface{}, opt Option) (*Mapper, error) {
+	toType, fromType, err := structTypes(to, from)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Mapper{mapping: getMapping(toType, fromType, opt), opt: opt}
+	if problems := m.validate(); len(problems) > 0 {
+		return nil, fmt.Errorf("%w from %v to %v: %s", ErrInvalidMapping, fromType, toType, strings.Join(problems, "; "))
+	}
+	return m, nil
+}
+
+// Fields returns the mapping table, in the order the fields are copied
+func (m *Mapper) Fields() []FieldMapping {
+	return m.mapping.fieldMappings(m.opt)
+}
+
+// String returns the mapping table, one destination per line
+func (m *Mapper) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v <- %v\n", m.mapping.to, m.mapping.from)
+	for _, field := range m.Fields() {
+		if field.Converter {
+			fmt.Fprintf(&b, "\t%s <- %s (%v, converter)\n", field.To, field.From, field.Kind)
+		} else {
+			fmt.Fprintf(&b, "\t%s <- %s (%v)\n", field.To, field.From, field.Kind)
+		}
+	}
+	return b.String()
+}
+
+// Map copies src into dst, dst must be a pointer to the destination type of the mapper and src
+// a value or a pointer of its source type
+func (m *Mapper) Map(dst, src interface{}) error {
+	dest := reflect.ValueOf(dst)
+	for dest.Kind() == reflect.Ptr && dest.Elem().Kind() == reflect.Ptr && !dest.Elem().IsNil() {
+		dest = dest.Elem()
+	}
+	if dest.Kind() != reflect.Ptr || dest.IsNil() || dest.Type().Elem() != m.mapping.to {
+		return fmt.Errorf("%w: expected a pointer to %v, got %T", ErrInvalidCopyDestination, m.mapping.to, dst)
+	}
+
+	source := indirect(reflect.ValueOf(src))
+	if !source.IsValid() || source.Type() != m.mapping.from {
+		return fmt.Errorf("%w: expected %v, got %T", ErrInvalidCopyFrom, m.mapping.from, src)
+	}
+
+	tagBitFlags := m.mapping.tagBitFlags()
+	if err := m.mapping.copy(dest.Elem(), source, tagBitFlags, m.opt.cloning(m.mapping.from)); err != nil {
+		return err
+	}
+	return checkBitFlags(tagBitFlags)
+}
+
+// validate returns the problems of the mapping: unknown tags, must fields without source and
+// fields of types that can't be copied
+func (m *Mapper) validate() (problems []string) {
+	for _, field := range deepFields(m.mapping.to) {
+		tag, ok := field.Tag.Lookup("copier")
+		if !ok {
+			continue
+		}
+		path := tagPath(tag)
+		for _, t := range strings.Split(tag, ",") {
+			if parseTags(t) != 0 {
+				continue
+			}
+			if t != path {
+				problems = append(problems, fmt.Sprintf("field %s has unknown tag %q", field.Name, t))
+			} else if _, _, ok := resolvePath(m.mapping.from, path); ok {
+				continue
+			} else if !strings.ContainsAny(path, ".[") {
+				problems = append(problems, fmt.Sprintf("field %s has unknown tag %q", field.Name, t))
+			} else if _, byName := m.mapping.from.FieldByName(field.Name); !byName {
+				// the field is copied by name when the source doesn't have the path
+				problems = append(problems, fmt.Sprintf("field %s has path %q not found in %v", field.Name, path, m.mapping.from))
+			}
+		}
+	}
+
+	copied := map[string]bool{}
+	for _, step := range m.mapping.fields {
+		if step.to == nil {
+			continue
+		}
+
+		toField := m.mapping.to.FieldByIndex(step.to)
+		if !m.mapping.settable(toField, m.opt) {
+			continue
+		}
+
+		copied[step.name] = true
+		if !copyable(toField.Type, step.fromType, m.opt) {
+			problems = append(problems, fmt.Sprintf("field %s can't be copied from %v to %v", step.name, step.fromType, toField.Type))
+		}
+	}
+
+	for _, step := range m.mapping.methods {
+		method, _ := reflect.PtrTo(m.mapping.from).MethodByName(step.name)
+		toField := m.mapping.to.FieldByIndex(step.to)
+		if toField.PkgPath != "" {
+			continue
+		}
+		if !copyable(toField.Type, method.Type.Out(0), m.opt) {
+			problems = append(problems, fmt.Sprintf("field %s can't be copied from method returning %v to %v", step.name, method.Type.Out(0), toField.Type))
+		}
+	}
+
+	problems = append(problems, m.mapping.strictOffenders(m.opt.Strict)...)
+
+	for _, field := range deepFields(m.mapping.to) {
+		flags := m.mapping.flags[field.Name]
+		if flags&tagMust != 0 && flags&tagIgnore == 0 && !copied[field.Name] {
+			problems = append(problems, fmt.Sprintf("field %s has must tag but has no source field", field.Name))
+		}
+	}
+	return problems
+}
+
+// structTypes returns the struct types of the samples to and from
+func structTypes(to, from interface{}) (toType, fromType reflect.Type, err error) {
+	if fromType = reflect.TypeOf(from); fromType == nil {
+		return nil, nil, ErrInvalidCopyFrom
+	}
+	if toType = reflect.TypeOf(to); toType == nil {
+		return nil, nil, ErrInvalidCopyDestination
+	}
+
+	fromType, _ = indirectType(fromType)
+	toType, _ = indirectType(toType)
+	if fromType.Kind() != reflect.Struct || toType.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("%w mapping from %v to %v", ErrNotSupported, fromType, toType)
+	}
+	return toType, fromType, nil
+}
+
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// copyable reports whether values of type from can be copied into values of type to
+func copyable(to, from reflect.Type, opt Option) bool {
+	if opt.IntToString == IntToStringError && intToString(to, from) && enumOf(to, from) == nil {
+		return false
+	}
+
+	if from.ConvertibleTo(to) {
+		return true
+	}
+
+	if _, ok := opt.converter(from, to); ok {
+		return true
+	}
+
+	if opt.convertible(to, from) {
+		return true
+	}
+
+	if reflect.PtrTo(to).Implements(scannerType) || from.Implements(valuerType) || reflect.PtrTo(from).Implements(valuerType) {
+		return true
+	}
+
+	if nullable := nullableOf(indirectElem(from)); nullable != nil && indirectElem(to) != indirectElem(from) {
+		return copyable(to, nullable.elem, opt)
+	}
+	if nullable := nullableOf(indirectElem(to)); nullable != nil && nullable.set != -1 && indirectElem(to) != indirectElem(from) {
+		return copyable(nullable.elem, from, opt)
+	}
+
+	switch {
+	case from.Kind() == reflect.Interface:
+		// depends on the dynamic type of the values
+		return true
+	case to.Kind() == reflect.Ptr:
+		return copyable(to.Elem(), from, opt)
+	case from.Kind() == reflect.Ptr:
+		return copyable(to, from.Elem(), opt)
+	case to.Kind() == reflect.Struct && from.Kind() == reflect.Struct:
+		return true
+	case to.Kind() == reflect.Slice && from.Kind() == reflect.Slice:
+		return copyable(to.Elem(), from.Elem(), opt)
+	case to.Kind() == reflect.Slice && from.Kind() == reflect.Struct:
+		return copyable(to.Elem(), from, opt)
+	case to.Kind() == reflect.Map && from.Kind() == reflect.Map:
+		return mapKeyCompatible(from.Key(), to.Key(), opt) && copyable(to.Elem(), from.Elem(), opt)
+	case to.Kind() == reflect.Slice && from.Kind() == reflect.Map:
+		_, _, ok := pairFields(indirectElem(to.Elem()))
+		return ok
+	case to.Kind() == reflect.Map && from.Kind() == reflect.Slice:
+		_, _, ok := pairFields(indirectElem(from.Elem()))
+		return ok
+	}
+	return false
+}
+
+// indirectElem returns the type pointed by t, if t is a pointer
+func indirectElem(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
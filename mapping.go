@@ -0,0 +1,744 @@
+package copier
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// structMapping describes how a struct type is copied into another struct type, it is computed
+// once per pair of types
+type structMapping struct {
+	to, from reflect.Type
+
+	// fields copies the source fields, in the order of deepFields, into dest fields or methods
+	fields []fieldStep
+
+	// methods copies the results of the source methods into dest fields
+	methods []methodStep
+
+	// flags holds the tag flags of the dest fields
+	flags map[string]uint8
+
+	unmatchedOnce              sync.Once
+	unmatchedTo, unmatchedFrom []string
+
+	// ambiguous fields of embedded structs, skipped unless a path tag picks one of them
+	ambiguousTo, ambiguousFrom []string
+}
+
+type fieldStep struct {
+	// name is the name of the dest field holding the flags
+	name  string
+	flags uint8
+
+	// paths of the source and dest fields, for reports
+	fromPath, toPath string
+
+	// from locates the source value, through nested structs, slices and maps for paths
+	from     sourcePath
+	fromType reflect.Type
+
+	// to is the index of the dest field, nil when copied with the dest method setter
+	to     []int
+	setter int
+
+	// embedded is true when an embedded struct is copied as a whole, names are the dest fields
+	// with flags copied with it
+	embedded bool
+	names    []string
+}
+
+type methodStep struct {
+	name string
+	to   []int
+
+	// indexes of the method in the method sets of the source pointer and value types, -1 if missing
+	ptrMethod   int
+	valueMethod int
+}
+
+type mappingKey struct {
+	to, from  reflect.Type
+	flatten   bool
+	separator string
+	// unexported is set when cloning unexported fields, which copies unexported embedded structs
+	unexported bool
+}
+
+var mappings sync.Map
+
+// getMapping returns the mapping of the given struct types, computing and caching it if needed
+func getMapping(toType, fromType reflect.Type, opt Option) *structMapping {
+	key := mappingKey{to: toType, from: fromType, flatten: opt.Flatten, separator: opt.FlattenSeparator, unexported: opt.DeepCopy && opt.CopyUnexported}
+	if m, ok := mappings.Load(key); ok {
+		return m.(*structMapping)
+	}
+
+	m, _ := mappings.LoadOrStore(key, newStructMapping(toType, fromType, opt))
+	return m.(*structMapping)
+}
+
+func newStructMapping(toType, fromType reflect.Type, opt Option) *structMapping {
+	m := &structMapping{to: toType, from: fromType, flags: getBitFlags(toType)}
+
+	// dest fields with a path tag are only copied from that path, when the source has it, such
+	// as when copying a tagged struct into a value of the same type
+	tagged := map[string]bool{}
+	for _, field := range deepFields(toType) {
+		if path := tagPath(field.Tag.Get("copier")); path != "" {
+			if _, _, ok := resolvePath(fromType, path); ok {
+				tagged[field.Name] = true
+			}
+		}
+	}
+
+	// Copy embedded structs as a whole into the dest embedded structs of the same name
+	var embedded [][]int
+	for _, field := range reflect.VisibleFields(fromType) {
+		if !field.Anonymous || indirectElem(field.Type).Kind() != reflect.Struct || hasPrefix(field.Index, embedded) {
+			continue
+		}
+
+		toField, ok := toType.FieldByName(field.Name)
+		if !ok || !toField.Anonymous || indirectElem(toField.Type).Kind() != reflect.Struct ||
+			parseTags(toField.Tag.Get("copier"))&tagIgnore != 0 || !m.settable(field, opt) || !m.settable(toField, opt) {
+			continue
+		}
+
+		step := fieldStep{name: field.Name, fromPath: field.Name, toPath: field.Name, from: sourcePath{{field: field.Index}}, fromType: field.Type, to: toField.Index, setter: -1, embedded: true}
+		for _, f := range deepFields(toType) {
+			if m.flags[f.Name] != 0 && hasPrefix(f.Index, [][]int{toField.Index}) {
+				step.names = append(step.names, f.Name)
+			}
+		}
+		embedded = append(embedded, field.Index)
+		m.fields = append(m.fields, step)
+	}
+
+	// Copy from source field to dest field or method
+	seen := map[string]bool{}
+	for _, field := range deepFields(fromType) {
+		name := field.Name
+		if seen[name] || hasPrefix(field.Index, embedded) {
+			continue
+		}
+		seen[name] = true
+
+		// Check if we should ignore copying
+		fieldFlags := m.flags[name]
+		if (fieldFlags & tagIgnore) != 0 {
+			continue
+		}
+
+		fromField, ok := fromType.FieldByName(name)
+		if !ok {
+			continue
+		}
+
+		step := fieldStep{name: name, flags: fieldFlags, fromPath: name, toPath: name, from: sourcePath{{field: fromField.Index}}, fromType: fromField.Type, setter: -1}
+
+		// a path tag on the source field is resolved against the dest, to build nested dest values
+		if path := tagPath(fromField.Tag.Get("copier")); path != "" {
+			if index, ok := fieldIndex(toType, path); ok {
+				step.name, step.flags, step.toPath, step.to = path, 0, path, index
+				m.fields = append(m.fields, step)
+				continue
+			}
+		}
+
+		if tagged[name] {
+			continue
+		}
+
+		if toField, ok := toType.FieldByName(name); ok {
+			step.to = toField.Index
+		} else if method, ok := reflect.PtrTo(toType).MethodByName(name); ok && method.Type.NumIn() == 2 && fromField.Type.AssignableTo(method.Type.In(1)) {
+			step.setter = method.Index
+		} else if opt.Flatten {
+			// unflatten CustomerName into Customer.Name
+			if index, path, ok := flattenedIndex(toType, name, opt.FlattenSeparator); ok {
+				step.name, step.flags, step.toPath, step.to = path, 0, path, index
+			}
+		}
+		m.fields = append(m.fields, step)
+	}
+
+	// Copy from source paths to dest fields
+	seen = map[string]bool{}
+	for _, field := range deepFields(toType) {
+		name := field.Name
+		if seen[name] || m.flags[name]&tagIgnore != 0 {
+			continue
+		}
+		seen[name] = true
+
+		toField, ok := toType.FieldByName(name)
+		if !ok {
+			continue
+		}
+
+		var (
+			from      sourcePath
+			valueType reflect.Type
+			path      = tagPath(toField.Tag.Get("copier"))
+		)
+		if path != "" {
+			from, valueType, ok = resolvePath(fromType, path)
+		} else if _, exists := fromType.FieldByName(name); !exists && opt.Flatten {
+			// flatten Customer.Name into CustomerName
+			var index []int
+			if index, path, ok = flattenedIndex(fromType, name, opt.FlattenSeparator); ok {
+				from, valueType = sourcePath{{field: index}}, fromType.FieldByIndex(index).Type
+			}
+		} else {
+			continue
+		}
+
+		if ok {
+			m.fields = append(m.fields, fieldStep{name: name, flags: m.flags[name], fromPath: path, toPath: name, from: from, fromType: valueType, to: toField.Index, setter: -1})
+		}
+	}
+
+	// Copy from from method to dest field
+	for _, field := range deepFields(toType) {
+		toField, ok := toType.FieldByName(field.Name)
+		if !ok {
+			continue
+		}
+
+		step := methodStep{name: field.Name, to: toField.Index, ptrMethod: -1, valueMethod: -1}
+		if method, ok := reflect.PtrTo(fromType).MethodByName(field.Name); ok && method.Type.NumIn() == 1 && method.Type.NumOut() == 1 {
+			step.ptrMethod = method.Index
+		}
+		if method, ok := fromType.MethodByName(field.Name); ok && method.Type.NumIn() == 1 && method.Type.NumOut() == 1 {
+			step.valueMethod = method.Index
+		}
+		if step.ptrMethod != -1 || step.valueMethod != -1 {
+			m.methods = append(m.methods, step)
+		}
+	}
+
+	// ambiguous fields are resolved when path tags pick every one of them
+	for _, field := range ambiguousFields(toType) {
+		if !m.picked(field, func(step fieldStep) []int { return step.to }) {
+			m.ambiguousTo = append(m.ambiguousTo, field.name)
+		}
+	}
+	for _, field := range ambiguousFields(fromType) {
+		if !m.picked(field, func(step fieldStep) []int { return step.from[0].field }) {
+			m.ambiguousFrom = append(m.ambiguousFrom, field.name)
+		}
+	}
+
+	return m
+}
+
+// fieldIndex returns the index of the field at the dotted path of t, such as Customer.Name,
+// through nested structs and pointers to structs
+func fieldIndex(t reflect.Type, path string) ([]int, bool) {
+	var index []int
+	for _, name := range strings.Split(path, ".") {
+		if t = indirectElem(t); t.Kind() != reflect.Struct {
+			return nil, false
+		}
+
+		field, ok := t.FieldByName(name)
+		if !ok {
+			return nil, false
+		}
+		index = append(index, field.Index...)
+		t = field.Type
+	}
+	return index, true
+}
+
+// sourcePath locates a value inside a source struct
+type sourcePath []pathSegment
+
+// pathSegment is a step of a source path: the index of a field through nested structs, the key
+// of a map entry or the index of a slice or array element
+type pathSegment struct {
+	field []int
+	key   reflect.Value
+	index int
+}
+
+// resolvePath returns the source path and the type of the value at path of t, such as
+// Profile.Addresses[0].City or Meta[region], through structs, pointers, slices, arrays and maps
+func resolvePath(t reflect.Type, path string) (sourcePath, reflect.Type, bool) {
+	tokens, ok := splitPath(path)
+	if !ok {
+		return nil, nil, false
+	}
+
+	var (
+		segments sourcePath
+		index    []int
+	)
+	for _, token := range tokens {
+		t = indirectElem(t)
+		if !token.bracket {
+			if t.Kind() != reflect.Struct {
+				return nil, nil, false
+			}
+			field, ok := t.FieldByName(token.value)
+			if !ok {
+				return nil, nil, false
+			}
+			index = append(index, field.Index...)
+			t = field.Type
+			continue
+		}
+
+		if index != nil {
+			segments = append(segments, pathSegment{field: index})
+			index = nil
+		}
+
+		switch t.Kind() {
+		case reflect.Slice, reflect.Array:
+			i, err := strconv.Atoi(token.value)
+			if err != nil || i < 0 {
+				return nil, nil, false
+			}
+			segments = append(segments, pathSegment{index: i})
+		case reflect.Map:
+			key, ok := mapKey(t.Key(), token.value)
+			if !ok {
+				return nil, nil, false
+			}
+			segments = append(segments, pathSegment{key: key})
+		default:
+			return nil, nil, false
+		}
+		t = t.Elem()
+	}
+
+	if index != nil {
+		segments = append(segments, pathSegment{field: index})
+	}
+	return segments, t, true
+}
+
+type pathToken struct {
+	value   string
+	bracket bool
+}
+
+// splitPath splits Addresses[0].City into the tokens Addresses, [0] and City
+func splitPath(path string) (tokens []pathToken, ok bool) {
+	for len(path) > 0 {
+		if path[0] == '[' {
+			end := strings.IndexByte(path, ']')
+			if end < 2 {
+				return nil, false
+			}
+			tokens = append(tokens, pathToken{value: path[1:end], bracket: true})
+			path = path[end+1:]
+		} else {
+			end := strings.IndexAny(path, ".[")
+			if end == -1 {
+				end = len(path)
+			}
+			if end == 0 {
+				return nil, false
+			}
+			tokens = append(tokens, pathToken{value: path[:end]})
+			path = path[end:]
+		}
+
+		if strings.HasPrefix(path, ".") {
+			if path = path[1:]; path == "" {
+				return nil, false
+			}
+		} else if path != "" && path[0] != '[' {
+			return nil, false
+		}
+	}
+	return tokens, len(tokens) > 0
+}
+
+// mapKey parses the key of a map path segment into a value of the key type
+func mapKey(t reflect.Type, key string) (reflect.Value, bool) {
+	value := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		value.SetString(key)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(key, 10, 64)
+		if err != nil || value.OverflowInt(i) {
+			return reflect.Value{}, false
+		}
+		value.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, err := strconv.ParseUint(key, 10, 64)
+		if err != nil || value.OverflowUint(u) {
+			return reflect.Value{}, false
+		}
+		value.SetUint(u)
+	default:
+		return reflect.Value{}, false
+	}
+	return value, true
+}
+
+// value returns the value at the path of source, nil pointers, missing map keys and out of
+// range indexes mean the value is not present
+func (p sourcePath) value(source reflect.Value) (reflect.Value, bool) {
+	for _, segment := range p {
+		for source.Kind() == reflect.Ptr {
+			if source.IsNil() {
+				return reflect.Value{}, false
+			}
+			source = source.Elem()
+		}
+
+		switch {
+		case segment.field != nil:
+			field, err := source.FieldByIndexErr(segment.field)
+			if err != nil {
+				return reflect.Value{}, false
+			}
+			source = field
+		case segment.key.IsValid():
+			if source = source.MapIndex(segment.key); !source.IsValid() {
+				return reflect.Value{}, false
+			}
+		default:
+			if segment.index >= source.Len() {
+				return reflect.Value{}, false
+			}
+			source = source.Index(segment.index)
+		}
+	}
+	return source, true
+}
+
+// rootField returns the top level field of a path, such as Profile for Profile.Addresses[0]
+func rootField(path string) string {
+	if end := strings.IndexAny(path, ".["); end != -1 {
+		return path[:end]
+	}
+	return path
+}
+
+// hasPrefix reports whether index starts with one of the prefixes
+func hasPrefix(index []int, prefixes [][]int) bool {
+	for _, prefix := range prefixes {
+		if len(index) >= len(prefix) && reflect.DeepEqual(index[:len(prefix)], prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// picked reports whether every field of the ambiguous field is copied by a step, index returns
+// the index of the field copied by the step
+func (m *structMapping) picked(field ambiguousField, index func(fieldStep) []int) bool {
+	for _, want := range field.indexes {
+		found := false
+		for _, step := range m.fields {
+			if hasPrefix(index(step), [][]int{want}) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// flattenedIndex returns the index and the path of the nested field of t whose path, joined
+// with the separator, is name, such as Customer.Name for CustomerName
+func flattenedIndex(t reflect.Type, name, separator string) ([]int, string, bool) {
+	if t = indirectElem(t); t.Kind() != reflect.Struct {
+		return nil, "", false
+	}
+
+	for _, field := range reflect.VisibleFields(t) {
+		prefix := field.Name + separator
+		if field.Anonymous || !field.IsExported() || len(name) <= len(prefix) || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		nested := indirectElem(field.Type)
+		if nested.Kind() != reflect.Struct {
+			continue
+		}
+
+		rest := name[len(prefix):]
+		if sub, ok := nested.FieldByName(rest); ok {
+			return append(append([]int{}, field.Index...), sub.Index...), field.Name + "." + rest, true
+		}
+		if index, path, ok := flattenedIndex(nested, rest, separator); ok {
+			return append(append([]int{}, field.Index...), index...), field.Name + "." + path, true
+		}
+	}
+	return nil, "", false
+}
+
+// tagBitFlags returns a copy of the dest tag flags, to record which fields have been copied
+func (m *structMapping) tagBitFlags() map[string]uint8 {
+	flags := make(map[string]uint8, len(m.flags))
+	for name, fieldFlags := range m.flags {
+		flags[name] = fieldFlags
+	}
+	return flags
+}
+
+// copy copies source into dest following the mapping, recording the copied fields in tagBitFlags
+func (m *structMapping) copy(dest, source reflect.Value, tagBitFlags map[string]uint8, opt Option) error {
+	// unexported fields are only cloned between values of the same type
+	copyUnexported := opt.DeepCopy && opt.CopyUnexported && m.from == m.to
+	if copyUnexported && !source.CanAddr() {
+		addressable := reflect.New(source.Type()).Elem()
+		addressable.Set(source)
+		source = addressable
+	}
+	if copyUnexported && !opt.clones(m.from) && hasUnexported(m.from) && dest.CanSet() {
+		// the internals of the types of other packages are not cloned
+		dest.Set(source)
+		return nil
+	}
+
+	for _, step := range m.fields {
+		fromField, ok := step.from.value(source)
+		if !ok || shouldIgnore(fromField, opt.IgnoreEmpty) {
+			// nil pointers, missing keys and indexes in the source path mean the field is not present
+			continue
+		}
+
+		if step.embedded && fromField.Kind() == reflect.Ptr && fromField.IsNil() {
+			// nil embedded structs are not present, the dest pointer is left as is
+			continue
+		}
+
+		if step.to == nil {
+			// try to set to method
+			if step.setter != -1 {
+				dest.Addr().Method(step.setter).Call([]reflect.Value{fromField})
+			}
+			continue
+		}
+
+		// process for nested anonymous field
+		destFieldNotSet := false
+		for idx := range step.to {
+			destField := dest.FieldByIndex(step.to[:idx+1])
+			if destField.Kind() != reflect.Ptr || !destField.IsNil() {
+				continue
+			}
+
+			if !destField.CanSet() {
+				if !copyUnexported {
+					destFieldNotSet = true
+					break
+				}
+				destField = exportedValue(destField)
+			}
+
+			// destField is a nil pointer that can be set
+			destField.Set(reflect.New(destField.Type().Elem()))
+		}
+
+		if destFieldNotSet {
+			break
+		}
+
+		toField := dest.FieldByIndex(step.to)
+		if copyUnexported && !toField.CanSet() {
+			toField = exportedValue(toField)
+			fromField = exportedValue(fromField)
+		}
+
+		if step.embedded {
+			// copy the embedded struct values, embedded pointers are not shared
+			toField, fromField = indirect(toField), indirect(fromField)
+		}
+
+		if toField.CanSet() {
+			if err := copyValue(toField, fromField, opt.at(pathNode{field: step.toPath})); err != nil {
+				return withPath(err, step.toPath)
+			}
+			if step.flags != 0 {
+				// Note that a copy was made
+				tagBitFlags[step.name] = step.flags | hasCopied
+			}
+			for _, name := range step.names {
+				tagBitFlags[name] |= hasCopied
+			}
+		}
+	}
+
+	for _, step := range m.methods {
+		var fromMethod reflect.Value
+		if source.CanAddr() && step.ptrMethod != -1 {
+			fromMethod = source.Addr().Method(step.ptrMethod)
+		} else if !source.CanAddr() && step.valueMethod != -1 {
+			fromMethod = source.Method(step.valueMethod)
+		} else {
+			continue
+		}
+
+		if toField := dest.FieldByIndex(step.to); toField.CanSet() && !shouldIgnore(fromMethod, opt.IgnoreEmpty) {
+			values := fromMethod.Call([]reflect.Value{})
+			if len(values) >= 1 {
+				if _, err := set(toField, values[0], opt.at(pathNode{field: step.name})); err != nil {
+					return withPath(err, step.name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// settable reports whether the dest field is copied to, unexported fields are only copied when
+// cloning values of the same type
+func (m *structMapping) settable(field reflect.StructField, opt Option) bool {
+	return field.PkgPath == "" || (opt.DeepCopy && opt.CopyUnexported && m.to == m.from)
+}
+
+// hasUnexported reports whether the struct type t has unexported fields
+func hasUnexported(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// packageOf returns the package of the type t, or of its elements, anonymous structs belong to
+// the package of their unexported fields
+func packageOf(t reflect.Type) string {
+	for t.Name() == "" {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		case reflect.Struct:
+			for i := 0; i < t.NumField(); i++ {
+				if pkg := t.Field(i).PkgPath; pkg != "" {
+					return pkg
+				}
+			}
+			return ""
+		default:
+			return ""
+		}
+	}
+	return t.PkgPath()
+}
+
+// fieldMappings returns the fields filled by the mapping, in the order they are copied
+func (m *structMapping) fieldMappings(opt Option) []FieldMapping {
+	var fields []FieldMapping
+	for _, step := range m.fields {
+		if step.to != nil {
+			toField := m.to.FieldByIndex(step.to)
+			if !m.settable(toField, opt) {
+				continue
+			}
+			fields = append(fields, FieldMapping{To: step.toPath, From: step.fromPath, Kind: MappingField, Converter: hasConverter(toField.Type, step.fromType, opt)})
+		} else if step.setter != -1 {
+			fields = append(fields, FieldMapping{To: step.toPath, From: step.fromPath, Kind: MappingSetter})
+		}
+	}
+
+	for _, step := range m.methods {
+		toField := m.to.FieldByIndex(step.to)
+		if toField.PkgPath != "" {
+			continue
+		}
+		method, _ := reflect.PtrTo(m.from).MethodByName(step.name)
+		fields = append(fields, FieldMapping{To: step.name, From: step.name, Kind: MappingGetter, Converter: hasConverter(toField.Type, method.Type.Out(0), opt)})
+	}
+	return fields
+}
+
+// unmatched returns the exported dest fields that are not filled, and the exported source fields
+// that are not copied, fields ignored with tags are not reported
+func (m *structMapping) unmatched() (to, from []string) {
+	m.unmatchedOnce.Do(func() {
+		var embeddedTo, embeddedFrom [][]int
+		for _, step := range m.fields {
+			if step.embedded {
+				embeddedTo, embeddedFrom = append(embeddedTo, step.to), append(embeddedFrom, step.from[0].field)
+			}
+		}
+
+		filled, consumed := map[string]bool{}, map[string]bool{}
+		for _, field := range m.fieldMappings(Option{}) {
+			// nested fields count for the top level field holding them
+			filled[rootField(field.To)] = true
+			if field.Kind != MappingGetter {
+				consumed[rootField(field.From)] = true
+			}
+		}
+
+		seen := map[string]bool{}
+		for _, field := range deepFields(m.to) {
+			if field.PkgPath == "" && !seen[field.Name] && !filled[field.Name] && !hasPrefix(field.Index, embeddedTo) && m.flags[field.Name]&tagIgnore == 0 {
+				m.unmatchedTo = append(m.unmatchedTo, field.Name)
+			}
+			seen[field.Name] = true
+		}
+
+		seen = map[string]bool{}
+		for _, field := range deepFields(m.from) {
+			if field.PkgPath == "" && !seen[field.Name] && !consumed[field.Name] && !hasPrefix(field.Index, embeddedFrom) && m.flags[field.Name]&tagIgnore == 0 {
+				m.unmatchedFrom = append(m.unmatchedFrom, field.Name)
+			}
+			seen[field.Name] = true
+		}
+	})
+	return m.unmatchedTo, m.unmatchedFrom
+}
+
+// checkStrict returns an error listing the unmatched fields required by the strict mode
+func (m *structMapping) checkStrict(mode StrictMode) error {
+	if offenders := m.strictOffenders(mode); len(offenders) > 0 {
+		return fmt.Errorf("%w copying %v into %v: %s", ErrUnmatchedFields, m.from, m.to, strings.Join(offenders, "; "))
+	}
+	return nil
+}
+
+// strictOffenders describes the unmatched fields required by the strict mode
+func (m *structMapping) strictOffenders(mode StrictMode) (offenders []string) {
+	if mode == 0 {
+		return nil
+	}
+
+	unmatchedTo, unmatchedFrom := m.unmatched()
+	if mode&StrictTo != 0 && len(unmatchedTo) > 0 {
+		offenders = append(offenders, "unmatched destination fields "+strings.Join(unmatchedTo, ", "))
+	}
+	if mode&StrictTo != 0 && len(m.ambiguousTo) > 0 {
+		offenders = append(offenders, "ambiguous destination fields "+strings.Join(m.ambiguousTo, ", "))
+	}
+	if mode&StrictFrom != 0 && len(unmatchedFrom) > 0 {
+		offenders = append(offenders, "unmatched source fields "+strings.Join(unmatchedFrom, ", "))
+	}
+	if mode&StrictFrom != 0 && len(m.ambiguousFrom) > 0 {
+		offenders = append(offenders, "ambiguous source fields "+strings.Join(m.ambiguousFrom, ", "))
+	}
+	return offenders
+}
+
+// hasConverter reports whether one of the converters converts from into to, or into the value
+// pointed by to
+func hasConverter(to, from reflect.Type, opt Option) bool {
+	if _, ok := opt.converter(from, to); ok {
+		return true
+	}
+	if to.Kind() == reflect.Ptr {
+		_, ok := opt.converter(from, to.Elem())
+		return ok
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package copier
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Nullable is implemented by optional values, such as Optional[T] types, which are copied into
+// and from plain values and pointers of T: valid values are copied from Get, invalid values
+// leave the destination untouched like sql.Null types do. Types are recognised by their methods,
+// they don't need to assert the interface
+type Nullable[T any] interface {
+	Valid() bool
+	Get() T
+}
+
+// NullableSetter is implemented by pointers to Nullable values, Set stores a valid value, Set
+// methods returning an error are recognised too
+type NullableSetter[T any] interface {
+	Nullable[T]
+	Set(T)
+}
+
+// nullableType holds the indexes of the methods of a Nullable type, in the method set of the
+// pointer type
+type nullableType struct {
+	valid, get int
+	elem       reflect.Type
+
+	// set is -1 when the pointer type isn't a NullableSetter
+	set      int
+	setError bool
+}
+
+var (
+	nullableTypes sync.Map
+	errorType     = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// nullableOf returns the methods of t when it is a Nullable struct type, nil otherwise
+func nullableOf(t reflect.Type) *nullableType {
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	if n, ok := nullableTypes.Load(t); ok {
+		return n.(*nullableType)
+	}
+
+	var nullable *nullableType
+	ptr := reflect.PtrTo(t)
+	valid, hasValid := ptr.MethodByName("Valid")
+	get, hasGet := ptr.MethodByName("Get")
+	if hasValid && hasGet && valid.Type.NumIn() == 1 && valid.Type.NumOut() == 1 && valid.Type.Out(0).Kind() == reflect.Bool &&
+		get.Type.NumIn() == 1 && get.Type.NumOut() == 1 {
+		nullable = &nullableType{valid: valid.Index, get: get.Index, elem: get.Type.Out(0), set: -1}
+
+		if set, ok := ptr.MethodByName("Set"); ok && set.Type.NumIn() == 2 && set.Type.In(1) == nullable.elem &&
+			(set.Type.NumOut() == 0 || (set.Type.NumOut() == 1 && set.Type.Out(0) == errorType)) {
+			nullable.set, nullable.setError = set.Index, set.Type.NumOut() == 1
+		}
+	}
+
+	n, _ := nullableTypes.LoadOrStore(t, nullable)
+	return n.(*nullableType)
+}
+
+// nullableConvertible reports whether values of type from are unwrapped into values of type to,
+// or wrapped into them, when one of them is Nullable
+func nullableConvertible(to, from reflect.Type, opt Option) bool {
+	if to, from = indirectElem(to), indirectElem(from); to == from {
+		return false
+	}
+
+	if nullable := nullableOf(from); nullable != nil {
+		return nullable.elem.ConvertibleTo(to) || opt.convertible(to, nullable.elem)
+	}
+	if nullable := nullableOf(to); nullable != nil && nullable.set != -1 {
+		return from.ConvertibleTo(nullable.elem) || opt.convertible(nullable.elem, from)
+	}
+	return false
+}
+
+// setNullable unwraps `from` or wraps it into `to` when one of them is Nullable, it reports
+// false when they are copied as usual
+func setNullable(to, from reflect.Value, opt Option) (bool, error) {
+	if from.Type() == to.Type() || to.Kind() == reflect.Interface {
+		return false, nil
+	}
+
+	if from.Kind() == reflect.Ptr && !from.IsNil() && nullableOf(from.Type().Elem()) != nil {
+		from = from.Elem()
+	}
+	if nullable := nullableOf(from.Type()); nullable != nil && indirectElem(to.Type()) != from.Type() {
+		// `from`           -> `to`
+		// Optional[string] -> string, *string
+		fromPtr := addressable(from)
+		if !fromPtr.Method(nullable.valid).Call(nil)[0].Bool() {
+			// if `from` is not valid do nothing with `to`
+			return true, nil
+		}
+		return true, copyValue(to, fromPtr.Method(nullable.get).Call(nil)[0], opt)
+	}
+
+	toType := to.Type()
+	if toType.Kind() == reflect.Ptr {
+		toType = toType.Elem()
+	}
+	if nullable := nullableOf(toType); nullable != nil && nullable.set != -1 && indirectElem(from.Type()) != toType {
+		// `from`          -> `to`
+		// string, *string -> Optional[string]
+		if from.Kind() == reflect.Ptr {
+			// if `from` is nil set `to` to nil, or do nothing with it
+			if from.IsNil() {
+				if to.Kind() == reflect.Ptr {
+					to.Set(reflect.Zero(to.Type()))
+				}
+				return true, nil
+			}
+			from = from.Elem()
+		}
+
+		value := reflect.New(nullable.elem).Elem()
+		if err := copyValue(value, from, opt); err != nil {
+			return false, err
+		}
+
+		if to.Kind() == reflect.Ptr {
+			if to.IsNil() {
+				to.Set(reflect.New(toType))
+			}
+			to = to.Elem()
+		}
+		results := to.Addr().Method(nullable.set).Call([]reflect.Value{value})
+		if nullable.setError && !results[0].IsNil() {
+			return false, results[0].Interface().(error)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// addressable returns a pointer to v, or to a copy of v if it isn't addressable
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v.Addr()
+	}
+	ptr := reflect.New(v.Type())
+	ptr.Elem().Set(v)
+	return ptr
+}
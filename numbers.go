@@ -0,0 +1,168 @@
+package copier
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+func isInt(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+func isUint(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uintptr
+}
+
+func isFloat(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
+
+func isNumber(kind reflect.Kind) bool {
+	return isInt(kind) || isUint(kind) || isFloat(kind)
+}
+
+// numberConvertible reports whether values of type from are copied into values of type to with
+// the Strconv option: numbers and bools from and into strings
+func numberConvertible(to, from reflect.Type) bool {
+	to, from = indirectElem(to), indirectElem(from)
+	switch {
+	case to.Kind() == reflect.String:
+		return isNumber(from.Kind()) || from.Kind() == reflect.Bool
+	case from.Kind() == reflect.String:
+		return isNumber(to.Kind()) || to.Kind() == reflect.Bool
+	}
+	return false
+}
+
+// setNumber copies from into to with the Strconv and CheckOverflow options, empty strings leave
+// `to` untouched
+func setNumber(to, from reflect.Value, opt Option) (bool, error) {
+	if !opt.Strconv && !opt.CheckOverflow {
+		return false, nil
+	}
+
+	toKind, fromKind := indirectElem(to.Type()).Kind(), indirectElem(from.Type()).Kind()
+	checked := opt.CheckOverflow && isNumber(toKind) && isNumber(fromKind) && toKind != fromKind
+	if !checked && !(opt.Strconv && numberConvertible(to.Type(), from.Type())) {
+		return false, nil
+	}
+
+	if from = indirect(from); !from.IsValid() {
+		if to.Kind() == reflect.Ptr {
+			to.Set(reflect.Zero(to.Type()))
+		}
+		return true, nil
+	}
+	if from.Kind() == reflect.String && from.String() == "" {
+		return true, nil
+	}
+	for to.Kind() == reflect.Ptr {
+		if to.IsNil() {
+			to.Set(reflect.New(to.Type().Elem()))
+		}
+		to = to.Elem()
+	}
+
+	var err error
+	switch {
+	case from.Kind() == reflect.String:
+		err = parseNumber(to, from.String())
+	case to.Kind() == reflect.String:
+		to.SetString(formatNumber(from))
+	default:
+		err = convertNumber(to, from)
+	}
+	if err != nil {
+		return false, &PathError{Err: err}
+	}
+	return true, nil
+}
+
+// parseNumber sets to, a number or a bool, with the value of s
+func parseNumber(to reflect.Value, s string) error {
+	switch kind := to.Kind(); {
+	case isInt(kind):
+		i, err := strconv.ParseInt(s, 10, to.Type().Bits())
+		if err != nil {
+			return err
+		}
+		to.SetInt(i)
+	case isUint(kind):
+		u, err := strconv.ParseUint(s, 10, to.Type().Bits())
+		if err != nil {
+			return err
+		}
+		to.SetUint(u)
+	case isFloat(kind):
+		f, err := strconv.ParseFloat(s, to.Type().Bits())
+		if err != nil {
+			return err
+		}
+		to.SetFloat(f)
+	default:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		to.SetBool(b)
+	}
+	return nil
+}
+
+// formatNumber returns v, a number or a bool, in base 10
+func formatNumber(v reflect.Value) string {
+	switch kind := v.Kind(); {
+	case isInt(kind):
+		return strconv.FormatInt(v.Int(), 10)
+	case isUint(kind):
+		return strconv.FormatUint(v.Uint(), 10)
+	case isFloat(kind):
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+	}
+	return strconv.FormatBool(v.Bool())
+}
+
+// convertNumber sets to with the number from, it fails with ErrOverflow when from doesn't fit
+// in to, or loses its fractional part or precision, floats only fail when they are out of range
+func convertNumber(to, from reflect.Value) error {
+	if isFloat(to.Kind()) && isFloat(from.Kind()) {
+		if to.OverflowFloat(from.Float()) {
+			return fmt.Errorf("%w: %v into %v", ErrOverflow, from, to.Type())
+		}
+		to.SetFloat(from.Float())
+		return nil
+	}
+
+	converted := from.Convert(to.Type())
+	fits := converted.Convert(from.Type()).Interface() == from.Interface()
+	switch {
+	case isInt(from.Kind()) && isUint(to.Kind()):
+		fits = fits && from.Int() >= 0
+	case isUint(from.Kind()) && isInt(to.Kind()):
+		fits = fits && converted.Int() >= 0
+	}
+	if !fits {
+		return fmt.Errorf("%w: %v into %v", ErrOverflow, from, to.Type())
+	}
+	to.Set(converted)
+	return nil
+}
+
+// intToString reports whether values of type from are integers copied into strings of type to
+func intToString(to, from reflect.Type) bool {
+	to, from = indirectElem(to), indirectElem(from)
+	return to.Kind() == reflect.String && (isInt(from.Kind()) || isUint(from.Kind()))
+}
+
+// setIntString copies the integer from into the string to, according to the IntToString policy
+func setIntString(to, from reflect.Value, opt Option) (bool, error) {
+	if opt.IntToString == IntToStringRune || !intToString(to.Type(), from.Type()) {
+		return false, nil
+	}
+
+	if opt.IntToString == IntToStringError && indirect(from).IsValid() {
+		return false, &PathError{Err: fmt.Errorf("%w: %v into %v", ErrNotSupported, from.Type(), to.Type())}
+	}
+	return setNumber(to, from, Option{Strconv: true})
+}
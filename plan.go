@@ -0,0 +1,31 @@
+package copier
+
+// CopyPlan describes what copying a struct into another would do, without copying anything
+type CopyPlan struct {
+	// Fields lists the destination fields and setter methods that are filled, and their sources
+	Fields []FieldMapping
+
+	// UnmatchedTo lists the exported destination fields that are left untouched
+	UnmatchedTo []string
+
+	// UnmatchedFrom lists the exported source fields that are not copied
+	UnmatchedFrom []string
+}
+
+// Plan returns the plan of copying from into to, both can be values or pointers of struct types
+func Plan(to, from interface{}) (*CopyPlan, error) {
+	return PlanWithOption(to, from, Option{})
+}
+
+// PlanWithOption returns the plan of copying from into to with the given options
+func PlanWithOption(to, from interface{}, opt Option) (*CopyPlan, error) {
+	toType, fromType, err := structTypes(to, from)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping := getMapping(toType, fromType, opt)
+	plan := &CopyPlan{Fields: mapping.fieldMappings(opt)}
+	plan.UnmatchedTo, plan.UnmatchedFrom = mapping.unmatched()
+	return plan, nil
+}
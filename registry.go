@@ -0,0 +1,75 @@
+package copier
+
+import (
+	"reflect"
+	"sync"
+)
+
+type typePair struct {
+	to   reflect.Type
+	from reflect.Type
+}
+
+var registry = struct {
+	sync.RWMutex
+	funcs map[typePair]func(to, from interface{}) error
+}{funcs: map[typePair]func(to, from interface{}) error{}}
+
+// Register registers a copy function for a pair of struct types, Copy dispatches to it instead
+// of using reflection, it is usually called from code generated by cmd/copiergen
+func Register[D, S any](fn func(to *D, from *S) error) {
+	pair := typePair{to: reflect.TypeOf((*D)(nil)).Elem(), from: reflect.TypeOf((*S)(nil)).Elem()}
+
+	registry.Lock()
+	defer registry.Unlock()
+	registry.funcs[pair] = func(to, from interface{}) error {
+		return fn(to.(*D), from.(*S))
+	}
+}
+
+// CopyField copies the value pointed by from into the value pointed by to, the same way Copy
+// copies a struct field, generated code uses it for fields it can't assign directly
+func CopyField(to, from interface{}) error {
+	toValue, fromValue := reflect.ValueOf(to), reflect.ValueOf(from)
+	if toValue.Kind() != reflect.Ptr || toValue.IsNil() {
+		return ErrInvalidCopyDestination
+	}
+	if fromValue.Kind() != reflect.Ptr || fromValue.IsNil() {
+		return ErrInvalidCopyFrom
+	}
+	return copyValue(toValue.Elem(), fromValue.Elem(), Option{})
+}
+
+// registered returns the registered copy function for the given values, together with the
+// values it should be called with
+func registered(toValue, fromValue interface{}) (fn func(to, from interface{}) error, to, from interface{}, ok bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+	if len(registry.funcs) == 0 {
+		return
+	}
+
+	toPtr := reflect.ValueOf(toValue)
+	for toPtr.Kind() == reflect.Ptr && toPtr.Elem().Kind() == reflect.Ptr && !toPtr.Elem().IsNil() {
+		toPtr = toPtr.Elem()
+	}
+	if toPtr.Kind() != reflect.Ptr || toPtr.IsNil() || toPtr.Elem().Kind() != reflect.Struct {
+		return
+	}
+
+	fromPtr := reflect.ValueOf(fromValue)
+	for fromPtr.Kind() == reflect.Ptr && fromPtr.Elem().Kind() == reflect.Ptr && !fromPtr.Elem().IsNil() {
+		fromPtr = fromPtr.Elem()
+	}
+	if fromPtr.Kind() == reflect.Struct {
+		addressable := reflect.New(fromPtr.Type())
+		addressable.Elem().Set(fromPtr)
+		fromPtr = addressable
+	}
+	if fromPtr.Kind() != reflect.Ptr || fromPtr.IsNil() || fromPtr.Elem().Kind() != reflect.Struct {
+		return
+	}
+
+	fn, ok = registry.funcs[typePair{to: toPtr.Type().Elem(), from: fromPtr.Type().Elem()}]
+	return fn, toPtr.Interface(), fromPtr.Interface(), ok
+}
@@ -0,0 +1,300 @@
+package copier
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// CopyRows copies every row of rows into the slice pointed by toValue, a *[]T or *[]*T where T is
+// a struct, appending a T per row. Columns are copied into the fields tagged with their name in
+// a `db` tag, or in a `copier` tag naming a single field, else into the fields named like them,
+// ignoring case and underscores, with the same conversions and sql.Scanner handling as Copy. rows is not closed
+func CopyRows(toValue interface{}, rows *sql.Rows) error {
+	return CopyRowsWithOption(toValue, rows, Option{})
+}
+
+// CopyRowsWithOption copies rows like CopyRows, with the given options, strict modes report
+// the unmatched columns and fields
+func CopyRowsWithOption(toValue interface{}, rows *sql.Rows, opt Option) error {
+	to := reflect.ValueOf(toValue)
+	if to.Kind() != reflect.Ptr || to.IsNil() || to.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("%w: expected a pointer to a slice, got %T", ErrInvalidCopyDestination, toValue)
+	}
+	to = to.Elem()
+
+	elemType := indirectElem(to.Type().Elem())
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: expected a slice of structs, got %T", ErrInvalidCopyDestination, toValue)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	fields, err := columnFields(elemType, columns, opt.Strict)
+	if err != nil {
+		return err
+	}
+	flags := getBitFlags(elemType)
+
+	values := make([]interface{}, len(columns))
+	for i := range values {
+		values[i] = new(interface{})
+	}
+
+	for i := 0; rows.Next(); i++ {
+		if err := opt.done(); err != nil {
+			return withPath(err, indexPath(i))
+		}
+
+		if err := rows.Scan(values...); err != nil {
+			return fmt.Errorf("row %d: %w", i, err)
+		}
+
+		dest := reflect.New(elemType).Elem()
+		tagBitFlags := make(map[string]uint8, len(flags))
+		for name, fieldFlags := range flags {
+			tagBitFlags[name] = fieldFlags
+		}
+
+		for c, field := range fields {
+			if field == nil {
+				continue
+			}
+
+			if err := setColumn(fieldByIndex(dest, field.Index), *values[c].(*interface{}), opt); err != nil {
+				return fmt.Errorf("row %d: column %s: %w", i, columns[c], err)
+			}
+			if fieldFlags := tagBitFlags[field.Name]; fieldFlags != 0 {
+				tagBitFlags[field.Name] = fieldFlags | hasCopied
+			}
+		}
+
+		if err := checkBitFlags(tagBitFlags); err != nil {
+			return fmt.Errorf("row %d: %w", i, err)
+		}
+
+		if to.Type().Elem().Kind() == reflect.Ptr {
+			dest = dest.Addr()
+		}
+		to.Set(reflect.Append(to, dest))
+	}
+	return rows.Err()
+}
+
+// columnFields returns the field each column is copied into, nil for the unmatched columns
+func columnFields(t reflect.Type, columns []string, mode StrictMode) ([]*reflect.StructField, error) {
+	var (
+		candidates    []*reflect.StructField
+		tagged, named = map[string]*reflect.StructField{}, map[string]*reflect.StructField{}
+		exported      = deepFields(t)
+	)
+	for i := range exported {
+		field := &exported[i]
+		dbTag := strings.Split(field.Tag.Get("db"), ",")[0]
+		if field.PkgPath != "" || dbTag == "-" || parseTags(field.Tag.Get("copier"))&tagIgnore != 0 || !allocatable(t, field.Index) {
+			continue
+		}
+
+		candidates = append(candidates, field)
+		if dbTag != "" {
+			tagged[dbTag] = field
+		} else if name := tagPath(field.Tag.Get("copier")); name != "" && !strings.ContainsAny(name, ".[") {
+			tagged[name] = field
+		} else {
+			named[normalizeColumn(field.Name)] = field
+		}
+	}
+
+	var (
+		fields           = make([]*reflect.StructField, len(columns))
+		filled           = map[string]bool{}
+		unmatchedColumns []string
+	)
+	for i, column := range columns {
+		if field, ok := tagged[column]; ok {
+			fields[i] = field
+		} else if field, ok := named[normalizeColumn(column)]; ok {
+			fields[i] = field
+		} else {
+			unmatchedColumns = append(unmatchedColumns, column)
+			continue
+		}
+		filled[fields[i].Name] = true
+	}
+
+	var problems []string
+	if mode&StrictFrom != 0 && len(unmatchedColumns) > 0 {
+		problems = append(problems, "unmatched columns "+strings.Join(unmatchedColumns, ", "))
+	}
+	if mode&StrictTo != 0 {
+		var unmatchedFields []string
+		for _, field := range candidates {
+			if !filled[field.Name] {
+				unmatchedFields = append(unmatchedFields, field.Name)
+			}
+		}
+		if len(unmatchedFields) > 0 {
+			problems = append(problems, "unmatched destination fields "+strings.Join(unmatchedFields, ", "))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("%w copying columns into %v: %s", ErrUnmatchedFields, t, strings.Join(problems, "; "))
+	}
+	return fields, nil
+}
+
+// normalizeColumn returns the name of a column or a field, ignoring case and underscores, so
+// user_name matches UserName
+func normalizeColumn(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+// allocatable reports whether the embedded pointers on the way to the nested field index can be
+// allocated, unexported embedded pointers can't be set
+func allocatable(t reflect.Type, index []int) bool {
+	for _, x := range index[:len(index)-1] {
+		field := t.Field(x)
+		if field.Type.Kind() == reflect.Ptr && field.PkgPath != "" {
+			return false
+		}
+		t = indirectElem(field.Type)
+	}
+	return true
+}
+
+// fieldByIndex returns the nested field of v, allocating the nil embedded pointers on its way
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// setColumn copies the value of a column into a field, NULL sets the field to its zero value,
+// unless it is a sql.Scanner
+func setColumn(field reflect.Value, value interface{}, opt Option) error {
+	if value == nil {
+		if scanner, ok := field.Addr().Interface().(sql.Scanner); ok {
+			return scanner.Scan(nil)
+		}
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	return copyValue(field, reflect.ValueOf(value), opt)
+}
+
+// Values returns the columns and the values of the exported fields of the struct fromValue, in
+// the order of the fields, to build inserts and updates. Columns are named by the `db` tags of
+// the fields, else by their snake cased names, such as user_name for UserName.
+// Values are converted by their driver.Valuer, if any, and nil pointers are NULL
+func Values(fromValue interface{}) (columns []string, values []driver.Value, err error) {
+	return ValuesWithOption(fromValue, Option{})
+}
+
+// ValuesWithOption returns the columns and values of fromValue like Values, with IgnoreEmpty the
+// fields with zero values, and the fields of nil embedded pointers, are left out
+func ValuesWithOption(fromValue interface{}, opt Option) (columns []string, values []driver.Value, err error) {
+	from := indirect(reflect.ValueOf(fromValue))
+	if !from.IsValid() || from.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("%w: expected a struct, got %T", ErrInvalidCopyFrom, fromValue)
+	}
+
+	for _, field := range deepFields(from.Type()) {
+		column, ok := fieldColumn(field)
+		if !ok {
+			continue
+		}
+
+		value, err := from.FieldByIndexErr(field.Index)
+		if err != nil {
+			// fields of nil embedded pointers are NULL, or empty
+			if !opt.IgnoreEmpty {
+				columns, values = append(columns, column), append(values, nil)
+			}
+			continue
+		}
+		if shouldIgnore(value, opt.IgnoreEmpty) {
+			continue
+		}
+
+		v, err := driverValue(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		columns, values = append(columns, column), append(values, v)
+	}
+	return columns, values, nil
+}
+
+// NamedArgs returns the values of the struct fromValue as sql.NamedArg, named by their columns
+// like Values, to be passed to the query functions of database/sql
+func NamedArgs(fromValue interface{}) ([]interface{}, error) {
+	columns, values, err := Values(fromValue)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]interface{}, len(columns))
+	for i, column := range columns {
+		args[i] = sql.Named(column, values[i])
+	}
+	return args, nil
+}
+
+// fieldColumn returns the column of an exported field, fields ignored with tags have none
+func fieldColumn(field reflect.StructField) (string, bool) {
+	dbTag := strings.Split(field.Tag.Get("db"), ",")[0]
+	if field.PkgPath != "" || dbTag == "-" || parseTags(field.Tag.Get("copier"))&tagIgnore != 0 {
+		return "", false
+	}
+
+	if dbTag != "" {
+		return dbTag, true
+	}
+	return snakeCase(field.Name), true
+}
+
+// driverValue converts a field into a driver.Value, with its driver.Valuer if it has one
+func driverValue(v reflect.Value) (driver.Value, error) {
+	if valuer, ok := driverValuer(v); ok {
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			return nil, nil
+		}
+		return valuer.Value()
+	}
+
+	value, err := driver.DefaultParameterConverter.ConvertValue(v.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotSupported, err)
+	}
+	return value, nil
+}
+
+// snakeCase returns the snake cased name of a field, such as user_name for UserName and
+// http_server for HTTPServer
+func snakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
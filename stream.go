@@ -0,0 +1,129 @@
+package copier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Iterator reads elements one at a time, like sql.Rows: Next advances to the next element, Scan
+// reads it into dst and Err returns the error which stopped the iteration, if any
+type Iterator[S any] interface {
+	Next() bool
+	Scan(dst *S) error
+	Err() error
+}
+
+// FromChan returns an Iterator over the elements received from ch, until ch is closed or ctx is
+// done
+func FromChan[S any](ctx context.Context, ch <-chan S) Iterator[S] {
+	return &chanIterator[S]{ctx: ctx, ch: ch}
+}
+
+type chanIterator[S any] struct {
+	ctx   context.Context
+	ch    <-chan S
+	value S
+	err   error
+}
+
+func (it *chanIterator[S]) Next() bool {
+	select {
+	case <-it.ctx.Done():
+		it.err = it.ctx.Err()
+		return false
+	case value, ok := <-it.ch:
+		it.value = value
+		return ok
+	}
+}
+
+func (it *chanIterator[S]) Scan(dst *S) error {
+	*dst = it.value
+	return nil
+}
+
+func (it *chanIterator[S]) Err() error {
+	return it.err
+}
+
+// CopyStream copies every element of it into a new slice of D, with the same field mapping as
+// CopyWithOption, until it is exhausted or ctx is done
+func CopyStream[S, D any](ctx context.Context, it Iterator[S], opt Option) ([]D, error) {
+	var dst []D
+	err := stream[S, D](ctx, it, opt, func(_ int, value D) error {
+		dst = append(dst, value)
+		return nil
+	})
+	return dst, err
+}
+
+// CopyBatches copies the elements of it into slices of up to size elements of D, and calls fn
+// with each of them, fn owns the slices it gets
+func CopyBatches[S, D any](ctx context.Context, it Iterator[S], size int, opt Option, fn func(batch []D) error) error {
+	if size < 1 {
+		return fmt.Errorf("%w batch size %d", ErrNotSupported, size)
+	}
+
+	batch := make([]D, 0, size)
+	err := stream[S, D](ctx, it, opt, func(_ int, value D) error {
+		if batch = append(batch, value); len(batch) < size {
+			return nil
+		}
+		full := batch
+		batch = make([]D, 0, size)
+		return fn(full)
+	})
+	if err == nil && len(batch) > 0 {
+		err = fn(batch)
+	}
+	return err
+}
+
+// CopyChan copies the elements received from in and sends them to out, until in is closed or
+// ctx is done, out is left open
+func CopyChan[S, D any](ctx context.Context, out chan<- D, in <-chan S, opt Option) error {
+	return stream[S, D](ctx, FromChan(ctx, in), opt, func(i int, value D) error {
+		select {
+		case out <- value:
+			return nil
+		case <-ctx.Done():
+			return withPath(&PathError{Err: ctx.Err()}, indexPath(i))
+		}
+	})
+}
+
+// stream copies the elements of it and calls emit with each copy
+func stream[S, D any](ctx context.Context, it Iterator[S], opt Option, emit func(i int, value D) error) error {
+	opt.ctx = ctx
+	for i := 0; ; i++ {
+		if err := opt.done(); err != nil {
+			return withPath(err, indexPath(i))
+		}
+
+		if !it.Next() {
+			if err := opt.done(); err != nil {
+				return withPath(err, indexPath(i))
+			}
+			return it.Err()
+		}
+
+		var src S
+		if err := it.Scan(&src); err != nil {
+			return fmt.Errorf("index %d: %w", i, err)
+		}
+
+		value, err := copyTo[D](src, opt.at(pathNode{index: i}))
+		if err != nil {
+			var pathErr *PathError
+			if errors.As(err, &pathErr) {
+				return withPath(err, indexPath(i))
+			}
+			return fmt.Errorf("index %d: %w", i, err)
+		}
+
+		if err := emit(i, value); err != nil {
+			return err
+		}
+	}
+}
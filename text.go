@@ -0,0 +1,73 @@
+package copier
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+)
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	stringerType        = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// textConvertible reports whether values of type from are copied into values of type to as
+// text: TextMarshaler and Stringer values into strings, strings into TextUnmarshaler values
+func textConvertible(to, from reflect.Type) bool {
+	to, from = indirectElem(to), indirectElem(from)
+	switch {
+	case to.Kind() == reflect.String && from.Kind() != reflect.String:
+		return reflect.PtrTo(from).Implements(textMarshalerType) || reflect.PtrTo(from).Implements(stringerType)
+	case from.Kind() == reflect.String && to.Kind() != reflect.String:
+		return reflect.PtrTo(to).Implements(textUnmarshalerType)
+	}
+	return false
+}
+
+// setText copies from into to as text, with the TextMarshaling option
+func setText(to, from reflect.Value, opt Option) (bool, error) {
+	if !opt.TextMarshaling || !textConvertible(to.Type(), from.Type()) {
+		return false, nil
+	}
+
+	if from = indirect(from); !from.IsValid() {
+		if to.Kind() == reflect.Ptr {
+			to.Set(reflect.Zero(to.Type()))
+		}
+		return true, nil
+	}
+	for to.Kind() == reflect.Ptr {
+		if to.IsNil() {
+			to.Set(reflect.New(to.Type().Elem()))
+		}
+		to = to.Elem()
+	}
+
+	if to.Kind() == reflect.String {
+		text, err := marshalText(from)
+		if err != nil {
+			return false, &PathError{Err: fmt.Errorf("marshal %v: %w", from.Type(), err)}
+		}
+		to.SetString(text)
+		return true, nil
+	}
+
+	unmarshaler := to.Addr().Interface().(encoding.TextUnmarshaler)
+	if err := unmarshaler.UnmarshalText([]byte(from.String())); err != nil {
+		return false, &PathError{Err: fmt.Errorf("unmarshal %q into %v: %w", from.String(), to.Type(), err)}
+	}
+	return true, nil
+}
+
+// marshalText returns the text of v, from MarshalText or else from String
+func marshalText(v reflect.Value) (string, error) {
+	switch i := addressable(v).Interface().(type) {
+	case encoding.TextMarshaler:
+		text, err := i.MarshalText()
+		return string(text), err
+	case fmt.Stringer:
+		return i.String(), nil
+	}
+	return "", fmt.Errorf("%w: %v has no text", ErrNotSupported, v.Type())
+}
@@ -0,0 +1,126 @@
+package copier
+
+import (
+	"database/sql"
+	"reflect"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	nullTimeType = reflect.TypeOf(sql.NullTime{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// isTime reports whether t holds a time.Time, as time.Time or sql.NullTime
+func isTime(t reflect.Type) bool {
+	return t == timeType || t == nullTimeType
+}
+
+// isUnix reports whether t holds Unix seconds
+func isUnix(t reflect.Type) bool {
+	return t.Kind() == reflect.Int64 && t != durationType
+}
+
+// timeConvertible reports whether values of type from are copied into values of type to with
+// the Times option: times from and into strings and Unix seconds, durations from and into strings
+func timeConvertible(to, from reflect.Type) bool {
+	to, from = indirectElem(to), indirectElem(from)
+	switch {
+	case isTime(from):
+		return to.Kind() == reflect.String || isUnix(to)
+	case isTime(to):
+		return from.Kind() == reflect.String || isUnix(from)
+	case from == durationType:
+		return to.Kind() == reflect.String
+	case to == durationType:
+		return from.Kind() == reflect.String
+	}
+	return false
+}
+
+// timeLayout returns the layout of the times copied from and into strings
+func (opt Option) timeLayout() string {
+	if opt.TimeLayout == "" {
+		return time.RFC3339
+	}
+	return opt.TimeLayout
+}
+
+// timeLocation returns the location of the times copied from strings and Unix seconds
+func (opt Option) timeLocation() *time.Location {
+	if opt.TimeLocation == nil {
+		return time.UTC
+	}
+	return opt.TimeLocation
+}
+
+// setTime copies from into to with the Times option, invalid sql.NullTime values and empty
+// strings leave `to` untouched
+func setTime(to, from reflect.Value, opt Option) (bool, error) {
+	if !opt.Times || !timeConvertible(to.Type(), from.Type()) {
+		return false, nil
+	}
+
+	if from = indirect(from); !from.IsValid() {
+		if to.Kind() == reflect.Ptr {
+			to.Set(reflect.Zero(to.Type()))
+		}
+		return true, nil
+	}
+	if nullTime, ok := from.Interface().(sql.NullTime); ok && !nullTime.Valid {
+		return true, nil
+	}
+	if from.Kind() == reflect.String && from.String() == "" {
+		return true, nil
+	}
+	for to.Kind() == reflect.Ptr {
+		if to.IsNil() {
+			to.Set(reflect.New(to.Type().Elem()))
+		}
+		to = to.Elem()
+	}
+
+	switch {
+	case to.Type() == durationType:
+		d, err := time.ParseDuration(from.String())
+		if err != nil {
+			return false, &PathError{Err: err}
+		}
+		to.SetInt(int64(d))
+	case from.Type() == durationType:
+		to.SetString(time.Duration(from.Int()).String())
+	case isTime(to.Type()):
+		var t time.Time
+		if from.Kind() == reflect.String {
+			parsed, err := time.ParseInLocation(opt.timeLayout(), from.String(), opt.timeLocation())
+			if err != nil {
+				return false, &PathError{Err: err}
+			}
+			t = parsed
+		} else {
+			t = time.Unix(from.Int(), 0).In(opt.timeLocation())
+		}
+
+		if to.Type() == nullTimeType {
+			to.Set(reflect.ValueOf(sql.NullTime{Time: t, Valid: true}))
+		} else {
+			to.Set(reflect.ValueOf(t))
+		}
+	default:
+		var t time.Time
+		switch value := from.Interface().(type) {
+		case time.Time:
+			t = value
+		case sql.NullTime:
+			t = value.Time
+		}
+
+		if to.Kind() == reflect.String {
+			to.SetString(t.In(opt.timeLocation()).Format(opt.timeLayout()))
+		} else {
+			to.SetInt(t.Unix())
+		}
+	}
+	return true, nil
+}